@@ -23,7 +23,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/http/pprof"
 	"os"
@@ -40,6 +42,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/httphandler"
 	"github.com/prometheus-community/windows_exporter/internal/log"
 	"github.com/prometheus-community/windows_exporter/internal/log/flag"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus-community/windows_exporter/pkg/collector"
 	"github.com/prometheus/common/version"
@@ -77,6 +80,10 @@ func run(ctx context.Context, args []string) int {
 			"YAML configuration file to use. Values set in this file will be overridden by CLI flags.",
 		).String()
 		webConfig   = webflag.AddFlags(app, ":9182")
+		configCheck = app.Flag(
+			"config.check",
+			"Check the configuration file and flags, print a report and exit without starting the exporter.",
+		).Bool()
 		metricsPath = app.Flag(
 			"telemetry.path",
 			"URL path for surfacing collected metrics.",
@@ -109,6 +116,26 @@ func run(ctx context.Context, args []string) int {
 			"process.memory-limit",
 			"Limit memory usage in bytes. This is a soft-limit and not guaranteed. 0 means no limit. Read more at https://pkg.go.dev/runtime/debug#SetMemoryLimit .",
 		).Default("200000000").Int64()
+		sdEnabled = app.Flag(
+			"web.sd.enabled",
+			"If true, windows_exporter will expose a Prometheus HTTP service discovery endpoint under /sd, describing this host.",
+		).Default("false").Bool()
+		sdLabels = app.Flag(
+			"web.sd.label",
+			"Static label added to the service discovery target, as key=value. Can be repeated.",
+		).StringMap()
+		pushGatewayURL = app.Flag(
+			"push.gateway.url",
+			"URL of a Prometheus Pushgateway. If set, all collected metrics are periodically pushed to it, in addition to serving them via HTTP.",
+		).Default("").String()
+		pushGatewayJob = app.Flag(
+			"push.gateway.job",
+			"Job name used when pushing metrics to the Pushgateway.",
+		).Default("windows_exporter").String()
+		pushGatewayInterval = app.Flag(
+			"push.gateway.interval",
+			"Interval in which metrics are pushed to the Pushgateway.",
+		).Default("1m").Duration()
 	)
 
 	logFile := &log.AllowedFile{}
@@ -174,6 +201,14 @@ func run(ctx context.Context, args []string) int {
 		collectors.Disable(slices.Compact(strings.Split(*disabledCollectors, ",")))
 	}
 
+	if *configCheck {
+		return checkConfig(os.Stdout, collectors.Validate())
+	}
+
+	// The integrity check of the performance counter registry runs in the background, so it doesn't delay the startup.
+	// Its result is always logged, but only exposed if the exporter metrics are enabled.
+	registryHealthCollector := pdh.NewRegistryHealthCollector(ctx, logger)
+
 	// Initialize collectors before loading
 	if err = collectors.Build(ctx, logger); err != nil {
 		for _, err := range utils.SplitError(err) {
@@ -193,10 +228,25 @@ func run(ctx context.Context, args []string) int {
 	mux.Handle("GET /health", httphandler.NewHealthHandler())
 	mux.Handle("GET /version", httphandler.NewVersionHandler())
 	mux.Handle("GET "+*metricsPath, httphandler.New(logger, collectors, &httphandler.Options{
-		DisableExporterMetrics: *disableExporterMetrics,
-		TimeoutMargin:          *timeoutMargin,
+		DisableExporterMetrics:  *disableExporterMetrics,
+		TimeoutMargin:           *timeoutMargin,
+		FlagCollector:           config.NewFlagCollector(app),
+		RegistryHealthCollector: registryHealthCollector,
 	}))
 
+	if *sdEnabled {
+		sdHandler, err := httphandler.NewSDHandler(logger, (*webConfig.WebListenAddresses)[0], *sdLabels)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "couldn't create service discovery handler",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+
+		mux.Handle("GET /sd", sdHandler)
+	}
+
 	if *debugEnabled {
 		mux.HandleFunc("GET /debug/pprof/", pprof.Index)
 		mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
@@ -232,6 +282,31 @@ func run(ctx context.Context, args []string) int {
 		close(errCh)
 	}()
 
+	if *pushGatewayURL != "" {
+		if *pushGatewayInterval <= 0 {
+			logger.LogAttrs(ctx, slog.LevelError, "push.gateway.interval must be greater than zero")
+
+			return 1
+		}
+
+		pushCtx, pushCancel := context.WithCancel(ctx)
+		defer pushCancel()
+
+		logger.LogAttrs(ctx, slog.LevelInfo, "pushing metrics to Pushgateway",
+			slog.String("url", *pushGatewayURL),
+			slog.String("job", *pushGatewayJob),
+			slog.Duration("interval", *pushGatewayInterval),
+		)
+
+		go func() {
+			if err := runPushGateway(pushCtx, logger, collectors, *pushGatewayURL, *pushGatewayJob, *pushGatewayInterval); err != nil {
+				logger.LogAttrs(pushCtx, slog.LevelError, "failed to start Pushgateway mode",
+					slog.Any("err", err),
+				)
+			}
+		}()
+	}
+
 	select {
 	case <-ctx.Done():
 		logger.LogAttrs(ctx, slog.LevelInfo, "Shutting down windows_exporter via kill signal")
@@ -322,6 +397,31 @@ func setPriorityWindows(ctx context.Context, logger *slog.Logger, pid int, prior
 	return nil
 }
 
+// checkConfig prints the validation result of each enabled collector and returns the exit code.
+func checkConfig(w io.Writer, result map[string]error) int {
+	exitCode := 0
+
+	for _, name := range slices.Sorted(maps.Keys(result)) {
+		if err := result[name]; err != nil {
+			_, _ = fmt.Fprintf(w, "collector %s: FAILED: %v\n", name, err)
+
+			exitCode = 1
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "collector %s: OK\n", name)
+	}
+
+	if exitCode == 0 {
+		_, _ = fmt.Fprintln(w, "configuration is valid")
+	} else {
+		_, _ = fmt.Fprintln(w, "configuration is invalid")
+	}
+
+	return exitCode
+}
+
 func expandEnabledCollectors(enabled string) []string {
 	expanded := strings.ReplaceAll(enabled, "[defaults]", collector.DefaultCollectors)
 
@@ -34,6 +34,25 @@ import (
 	"golang.org/x/sys/windows"
 )
 
+func TestCheckConfig(t *testing.T) {
+	t.Parallel()
+
+	var report strings.Builder
+
+	exitCode := checkConfig(&report, map[string]error{
+		"os":     nil,
+		"hyperv": errors.New("unknown collector: foo"),
+	})
+
+	require.Equal(t, 1, exitCode)
+	require.Equal(t, "collector hyperv: FAILED: unknown collector: foo\ncollector os: OK\nconfiguration is invalid\n", report.String())
+
+	report.Reset()
+
+	require.Equal(t, 0, checkConfig(&report, map[string]error{"os": nil}))
+	require.Equal(t, "collector os: OK\nconfiguration is valid\n", report.String())
+}
+
 //nolint:tparallel
 func TestRun(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors/version"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// runPushGateway periodically collects all enabled collectors and pushes the metrics to a Prometheus Pushgateway,
+// until ctx is canceled. The metrics are grouped by job and the hostname as instance label.
+func runPushGateway(ctx context.Context, logger *slog.Logger, collectors *collector.Collection, url, job string, interval time.Duration) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to get hostname: %w", err)
+	}
+
+	collectionHandler, err := collectors.NewHandler(interval, logger, nil)
+	if err != nil {
+		return fmt.Errorf("couldn't create collector handler: %w", err)
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(version.NewCollector("windows_exporter"))
+
+	if err := reg.Register(collectionHandler); err != nil {
+		return fmt.Errorf("couldn't register Prometheus collector: %w", err)
+	}
+
+	pusher := push.New(url, job).
+		Gatherer(reg).
+		Grouping("instance", hostname).
+		Client(&http.Client{Timeout: interval})
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := pusher.PushContext(ctx); err != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "failed to push metrics to Pushgateway",
+				slog.String("url", url),
+				slog.Any("err", err),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -132,7 +132,8 @@ func (c *Collector) Close() error {
 	return nil
 }
 
-func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+// Validate checks the configuration of the collector without accessing PDH or WMI.
+func (c *Collector) Validate() error {
 	for _, collector := range c.config.CollectorsEnabled {
 		if !slices.Contains([]string{subCollectorMetrics, subCollectorWMIStats}, collector) {
 			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
@@ -141,6 +142,14 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 		}
 	}
 
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
 		if err := c.buildMetricsCollector(logger); err != nil {
 			return err
@@ -299,7 +308,7 @@ func (c *Collector) buildMetricsCollector(logger *slog.Logger) error {
 
 	var err error
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "DNS", pdh.InstancesAll)
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "DNS", pdh.InstancesAll, pdh.WithCollectorName(Name))
 	if err != nil {
 		return fmt.Errorf("failed to create DNS collector: %w", err)
 	}
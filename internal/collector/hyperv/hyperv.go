@@ -18,41 +18,81 @@
 package hyperv
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 const (
 	Name = "hyperv"
 
+	subCollectorClusterAffinity                  = "cluster_affinity"
+	subCollectorClusterVMStartupPriority         = "cluster_vm_startup_priority"
 	subCollectorDataStore                        = "datastore"
 	subCollectorDynamicMemoryBalancer            = "dynamic_memory_balancer"
 	subCollectorDynamicMemoryVM                  = "dynamic_memory_vm"
+	subCollectorEnhancedSession                  = "enhanced_session"
+	subCollectorHostDriver                       = "host_driver"
+	subCollectorHostTCP                          = "host_tcp"
 	subCollectorHypervisorLogicalProcessor       = "hypervisor_logical_processor"
 	subCollectorHypervisorRootPartition          = "hypervisor_root_partition"
 	subCollectorHypervisorRootVirtualProcessor   = "hypervisor_root_virtual_processor"
 	subCollectorHypervisorVirtualProcessor       = "hypervisor_virtual_processor"
 	subCollectorLegacyNetworkAdapter             = "legacy_network_adapter"
+	subCollectorMpio                             = "mpio"
+	subCollectorNicConfig                        = "nic_config"
+	subCollectorPowerActions                     = "power_actions"
+	subCollectorReservationUtilization           = "reservation_utilization"
+	subCollectorSecureBoot                       = "secure_boot"
+	subCollectorSriov                            = "sriov"
+	subCollectorStorageDriver                    = "storage_driver"
+	subCollectorStorageQoS                       = "storage_qos"
+	subCollectorVirtualIDEController             = "virtual_ide_controller"
 	subCollectorVirtualMachineHealthSummary      = "virtual_machine_health_summary"
 	subCollectorVirtualMachineVidPartition       = "virtual_machine_vid_partition"
 	subCollectorVirtualNetworkAdapter            = "virtual_network_adapter"
 	subCollectorVirtualNetworkAdapterDropReasons = "virtual_network_adapter_drop_reasons"
 	subCollectorVirtualSMB                       = "virtual_smb"
 	subCollectorVirtualStorageDevice             = "virtual_storage_device"
+	subCollectorVirtualStorageDeviceISO          = "virtual_storage_device_iso"
 	subCollectorVirtualSwitch                    = "virtual_switch"
+	subCollectorVMMemory                         = "vm_memory"
+	subCollectorVMNetworkAdapter                 = "vm_network_adapter"
+	subCollectorVMNUMA                           = "vm_numa"
+	subCollectorVMOwnership                      = "vm_ownership"
+	subCollectorVMRemoting                       = "vm_remoting"
+	subCollectorVMSecurity                       = "vm_security"
+	subCollectorVMVCPU                           = "vm_vcpu"
+	subCollectorVMWorkerProcess                  = "vm_worker_process"
+	subCollectorVSwitchTeam                      = "vswitch_team"
+)
+
+const (
+	virtualStorageDeviceLabelStyleFull     = "full"
+	virtualStorageDeviceLabelStyleFilename = "filename"
 )
 
 type Config struct {
-	CollectorsEnabled []string `yaml:"enabled"`
+	CollectorsEnabled              []string `yaml:"enabled"`
+	CounterTypes                   bool     `yaml:"counter-types"`
+	VirtualStorageDeviceLabelStyle string   `yaml:"virtual-storage-device-label-style"`
+	IncludeLegacyDevices           bool     `yaml:"include-legacy-devices"`
+	TargetQueueDepth               float64  `yaml:"target-queue-depth"`
+	TargetQueueDepthOverrides      []string `yaml:"target-queue-depth-overrides"`
 }
 
 //nolint:gochecknoglobals
@@ -74,31 +114,77 @@ var ConfigDefaults = Config{
 		subCollectorVirtualStorageDevice,
 		subCollectorVirtualSwitch,
 	},
+	CounterTypes:                   false,
+	VirtualStorageDeviceLabelStyle: virtualStorageDeviceLabelStyleFull,
+	IncludeLegacyDevices:           false,
+	TargetQueueDepth:               0,
+	TargetQueueDepthOverrides:      []string{},
 }
 
 // Collector is a Prometheus Collector for hyper-v.
 type Collector struct {
+	collectorClusterAffinity
+	collectorClusterVMStartupPriority
 	collectorDataStore
 	collectorDynamicMemoryBalancer
 	collectorDynamicMemoryVM
+	collectorEnhancedSession
+	collectorHostDriver
+	collectorHostTCP
 	collectorHypervisorLogicalProcessor
 	collectorHypervisorRootPartition
 	collectorHypervisorRootVirtualProcessor
 	collectorHypervisorVirtualProcessor
 	collectorLegacyNetworkAdapter
+	collectorMpio
+	collectorNicConfig
+	collectorPowerActions
+	collectorReservationUtilization
+	collectorSecureBoot
+	collectorVMMemory
+	collectorVMNetworkAdapter
+	collectorVMNUMA
+	collectorVMRemoting
+	collectorVMSecurity
+	collectorStorageDriver
+	collectorStorageQoS
+	collectorSriov
+	collectorVirtualIDEController
 	collectorVirtualMachineHealthSummary
 	collectorVirtualMachineVidPartition
 	collectorVirtualNetworkAdapter
 	collectorVirtualNetworkAdapterDropReasons
 	collectorVirtualSMB
 	collectorVirtualStorageDevice
+	collectorVirtualStorageDeviceISO
 	collectorVirtualSwitch
+	collectorVMOwnership
+	collectorVMVCPU
+	collectorVMWorkerProcess
+	collectorVSwitchTeam
 
-	config Config
-	logger *slog.Logger
+	collectorCounterTypes
+	collectorPerfCountersRegistered
 
-	collectorFns []func(ch chan<- prometheus.Metric) error
+	config    Config
+	logger    *slog.Logger
+	miSession *mi.Session
+
+	// collectorFns are the collect functions of the enabled sub-collectors by name.
+	collectorFns map[string]func(ch chan<- prometheus.Metric) error
 	closeFns     []func()
+
+	// subCollectorAvailability reports for each enabled sub-collector whether it was built successfully.
+	subCollectorAvailability map[string]bool
+
+	subCollectorSampleTimestamp *prometheus.Desc
+	subCollectorAvailable       *prometheus.Desc
+
+	// ctx is cancelled by Close. Background goroutines of the sub-collectors are started
+	// with runInBackground and have to return once ctx is done.
+	ctx              context.Context //nolint:containedctx
+	cancel           context.CancelFunc
+	backgroundWorker sync.WaitGroup
 }
 
 func New(config *Config) *Collector {
@@ -110,6 +196,10 @@ func New(config *Config) *Collector {
 		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
 	}
 
+	if config.VirtualStorageDeviceLabelStyle == "" {
+		config.VirtualStorageDeviceLabelStyle = ConfigDefaults.VirtualStorageDeviceLabelStyle
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -123,16 +213,49 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 	}
 	c.config.CollectorsEnabled = make([]string, 0)
 
-	var collectorsEnabled string
+	var collectorsEnabled, targetQueueDepthOverrides string
 
 	app.Flag(
 		"collector.hyperv.enabled",
 		"Comma-separated list of collectors to use.",
 	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
+	app.Flag(
+		"collector.hyperv.counter-types",
+		"If enabled, the PDH counter type of each performance counter is exposed as windows_hyperv_perf_counter_type metric.",
+	).Default(strconv.FormatBool(ConfigDefaults.CounterTypes)).BoolVar(&c.config.CounterTypes)
+
+	app.Flag(
+		"collector.hyperv.virtual-storage-device.label-style",
+		"Style of the device label of the virtual storage device metrics. Possible values: full, filename.",
+	).Default(ConfigDefaults.VirtualStorageDeviceLabelStyle).StringVar(&c.config.VirtualStorageDeviceLabelStyle)
+
+	app.Flag(
+		"collector.hyperv.include-legacy-devices",
+		"If enabled, legacy emulated devices of generation 1 VMs, e.g. virtual floppy disks, are included in the virtual storage device and virtual network adapter metrics.",
+	).Default(strconv.FormatBool(ConfigDefaults.IncludeLegacyDevices)).BoolVar(&c.config.IncludeLegacyDevices)
+
+	app.Flag(
+		"collector.hyperv.target-queue-depth",
+		"Target queue depth of the virtual storage devices. If greater than 0, the queue length divided by the target queue depth is exposed as windows_hyperv_virtual_storage_device_queue_saturation metric.",
+	).Default(strconv.FormatFloat(ConfigDefaults.TargetQueueDepth, 'f', -1, 64)).Float64Var(&c.config.TargetQueueDepth)
+
+	app.Flag(
+		"collector.hyperv.target-queue-depth-overrides",
+		`Comma-separated list of per-path target queue depths in the format <path>=<depth>, e.g. D:\VMs\Tier1=64. The override with the longest matching path prefix wins.`,
+	).Default(strings.Join(ConfigDefaults.TargetQueueDepthOverrides, ",")).StringVar(&targetQueueDepthOverrides)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
 
+		c.config.TargetQueueDepthOverrides = make([]string, 0)
+
+		for override := range strings.SplitSeq(targetQueueDepthOverrides, ",") {
+			if override = strings.TrimSpace(override); override != "" {
+				c.config.TargetQueueDepthOverrides = append(c.config.TargetQueueDepthOverrides, override)
+			}
+		}
+
 		return nil
 	})
 
@@ -144,6 +267,12 @@ func (c *Collector) GetName() string {
 }
 
 func (c *Collector) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+
+	c.backgroundWorker.Wait()
+
 	for _, fn := range c.closeFns {
 		fn()
 	}
@@ -151,21 +280,167 @@ func (c *Collector) Close() error {
 	return nil
 }
 
-func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+// runInBackground runs fn in a goroutine until the context passed to fn is cancelled by Close.
+// Close waits for fn to return.
+func (c *Collector) runInBackground(fn func(ctx context.Context)) {
+	c.backgroundWorker.Go(func() {
+		fn(c.ctx)
+	})
+}
+
+// Validate checks the configuration of the collector without accessing PDH or WMI.
+func (c *Collector) Validate() error {
+	subCollectors := c.subCollectors()
+
+	for _, name := range c.config.CollectorsEnabled {
+		if _, ok := subCollectors[name]; !ok {
+			return fmt.Errorf("unknown collector: %s", name)
+		}
+	}
+
+	switch c.config.VirtualStorageDeviceLabelStyle {
+	case virtualStorageDeviceLabelStyleFull, virtualStorageDeviceLabelStyleFilename:
+	default:
+		return fmt.Errorf("unknown virtual storage device label style: %s. Possible values: %s, %s",
+			c.config.VirtualStorageDeviceLabelStyle, virtualStorageDeviceLabelStyleFull, virtualStorageDeviceLabelStyleFilename,
+		)
+	}
+
+	if _, err := parseTargetQueueDepths(c.config.TargetQueueDepth, c.config.TargetQueueDepthOverrides); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
-	c.collectorFns = make([]func(ch chan<- prometheus.Metric) error, 0, len(c.config.CollectorsEnabled))
+	c.miSession = miSession
+	c.collectorFns = make(map[string]func(ch chan<- prometheus.Metric) error, len(c.config.CollectorsEnabled))
 	c.closeFns = make([]func(), 0, len(c.config.CollectorsEnabled))
+	c.ctx, c.cancel = context.WithCancel(context.Background())
 
 	if len(c.config.CollectorsEnabled) == 0 {
 		return nil
 	}
 
-	subCollectors := map[string]struct {
-		build          func() error
-		collect        func(ch chan<- prometheus.Metric) error
-		close          func()
-		minBuildNumber uint16
-	}{
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	// Result must order, to prevent test failures.
+	sort.Strings(c.config.CollectorsEnabled)
+
+	errs := c.buildSubCollectors(c.subCollectors, osversion.Build())
+
+	if c.config.CounterTypes {
+		c.buildCounterTypes()
+	}
+
+	c.buildPerfCountersRegistered()
+
+	c.subCollectorSampleTimestamp = bdf.NewDesc(
+		Name,
+		"subcollector_sample_timestamp_seconds",
+		"Unix timestamp at which the sub-collector started sampling its counters in the last scrape. "+
+			"Each sub-collector samples its counters separately, the difference between sub-collectors is the skew of their values.",
+		[]string{"collector"},
+	)
+
+	// A failing sub-collector only disables itself. The build fails, if none of the sub-collectors
+	// could be built, e.g. on hosts without Hyper-V.
+	if len(c.collectorFns) == 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// buildSubCollectors builds the enabled sub-collectors. A sub-collector which fails to build is closed, disabled
+// and exposed as unavailable, while the other sub-collectors keep working. The build errors are returned.
+func (c *Collector) buildSubCollectors(subCollectors func() map[string]subCollector, buildNumber uint16) []error {
+	c.subCollectorAvailability = make(map[string]bool, len(c.config.CollectorsEnabled))
+	c.subCollectorAvailable = bdf.NewDesc(
+		Name,
+		"subcollector_available",
+		"1 if the sub-collector was built successfully, 0 if it is disabled because its build failed or the Windows build is too old. The reason is logged at startup.",
+		[]string{"name"},
+	)
+
+	errs := make([]error, 0, len(c.config.CollectorsEnabled))
+
+	for _, name := range c.config.CollectorsEnabled {
+		c.subCollectorAvailability[name] = false
+
+		subCollector := subCollectors()[name]
+
+		if buildNumber < subCollector.minBuildNumber {
+			c.logger.Warn(fmt.Sprintf(
+				"collector %s requires windows build version %d. Current build version: %d",
+				name, subCollector.minBuildNumber, buildNumber,
+			))
+
+			continue
+		}
+
+		err := subCollector.build()
+
+		// The close function is looked up again after the build,
+		// since it may be bound to the performance counter collector created by the build.
+		closeFn := subCollectors()[name].close
+
+		if err != nil {
+			closeFn()
+
+			c.logger.Warn("failed to build sub-collector, disabling it",
+				slog.String("sub_collector", name),
+				slog.Any("err", err),
+			)
+
+			errs = append(errs, fmt.Errorf("failed to build %s collector: %w", name, err))
+
+			continue
+		}
+
+		c.collectorFns[name] = subCollector.collect
+		c.closeFns = append(c.closeFns, closeFn)
+		c.subCollectorAvailability[name] = true
+	}
+
+	return errs
+}
+
+func (c *Collector) collectSubCollectorAvailable(ch chan<- prometheus.Metric) {
+	for name, available := range c.subCollectorAvailability {
+		ch <- prometheus.MustNewConstMetric(
+			c.subCollectorAvailable,
+			prometheus.GaugeValue,
+			utils.BoolToFloat(available),
+			name,
+		)
+	}
+}
+
+type subCollector struct {
+	build          func() error
+	collect        func(ch chan<- prometheus.Metric) error
+	close          func()
+	minBuildNumber uint16
+}
+
+// subCollectors returns the available sub-collectors. The functions are not called.
+func (c *Collector) subCollectors() map[string]subCollector {
+	return map[string]subCollector{
+		subCollectorClusterAffinity: {
+			build:   c.buildClusterAffinity,
+			collect: c.collectClusterAffinity,
+			close:   func() {},
+		},
+		subCollectorClusterVMStartupPriority: {
+			build:   c.buildClusterVMStartupPriority,
+			collect: c.collectClusterVMStartupPriority,
+			close:   func() {},
+		},
 		subCollectorDataStore: {
 			build:          c.buildDataStore,
 			collect:        c.collectDataStore,
@@ -182,6 +457,21 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 			collect: c.collectDynamicMemoryVM,
 			close:   c.perfDataCollectorDynamicMemoryVM.Close,
 		},
+		subCollectorEnhancedSession: {
+			build:   c.buildEnhancedSession,
+			collect: c.collectEnhancedSession,
+			close:   func() {},
+		},
+		subCollectorHostDriver: {
+			build:   c.buildHostDriver,
+			collect: c.collectHostDriver,
+			close:   func() {},
+		},
+		subCollectorHostTCP: {
+			build:   c.buildHostTCP,
+			collect: c.collectHostTCP,
+			close:   c.closeHostTCP,
+		},
 		subCollectorHypervisorLogicalProcessor: {
 			build:   c.buildHypervisorLogicalProcessor,
 			collect: c.collectHypervisorLogicalProcessor,
@@ -195,7 +485,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		subCollectorHypervisorRootVirtualProcessor: {
 			build:   c.buildHypervisorRootVirtualProcessor,
 			collect: c.collectHypervisorRootVirtualProcessor,
-			close:   c.perfDataCollectorHypervisorRootVirtualProcessor.Close,
+			close:   c.closeHypervisorRootVirtualProcessor,
 		},
 		subCollectorHypervisorVirtualProcessor: {
 			build:   c.buildHypervisorVirtualProcessor,
@@ -207,6 +497,51 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 			collect: c.collectLegacyNetworkAdapter,
 			close:   c.perfDataCollectorLegacyNetworkAdapter.Close,
 		},
+		subCollectorMpio: {
+			build:   c.buildMpio,
+			collect: c.collectMpio,
+			close:   func() {},
+		},
+		subCollectorNicConfig: {
+			build:   c.buildNicConfig,
+			collect: c.collectNicConfig,
+			close:   func() {},
+		},
+		subCollectorPowerActions: {
+			build:   c.buildPowerActions,
+			collect: c.collectPowerActions,
+			close:   func() {},
+		},
+		subCollectorStorageDriver: {
+			build:   c.buildStorageDriver,
+			collect: c.collectStorageDriver,
+			close:   func() {},
+		},
+		subCollectorReservationUtilization: {
+			build:   c.buildReservationUtilization,
+			collect: c.collectReservationUtilization,
+			close:   c.closeReservationUtilization,
+		},
+		subCollectorSecureBoot: {
+			build:   c.buildSecureBoot,
+			collect: c.collectSecureBoot,
+			close:   func() {},
+		},
+		subCollectorStorageQoS: {
+			build:   c.buildStorageQoS,
+			collect: c.collectStorageQoS,
+			close:   func() {},
+		},
+		subCollectorSriov: {
+			build:   c.buildSriov,
+			collect: c.collectSriov,
+			close:   func() {},
+		},
+		subCollectorVirtualIDEController: {
+			build:   c.buildVirtualIDEController,
+			collect: c.collectVirtualIDEController,
+			close:   c.perfDataCollectorVirtualIDEController.Close,
+		},
 		subCollectorVirtualMachineHealthSummary: {
 			build:   c.buildVirtualMachineHealthSummary,
 			collect: c.collectVirtualMachineHealthSummary,
@@ -238,69 +573,115 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 			collect: c.collectVirtualStorageDevice,
 			close:   c.perfDataCollectorVirtualStorageDevice.Close,
 		},
+		subCollectorVirtualStorageDeviceISO: {
+			build:   c.buildVirtualStorageDeviceISO,
+			collect: c.collectVirtualStorageDeviceISO,
+			close:   func() {},
+		},
 		subCollectorVirtualSwitch: {
 			build:   c.buildVirtualSwitch,
 			collect: c.collectVirtualSwitch,
 			close:   c.perfDataCollectorVirtualSwitch.Close,
 		},
+		subCollectorVMMemory: {
+			build:   c.buildVMMemory,
+			collect: c.collectVMMemory,
+			close:   func() {},
+		},
+		subCollectorVMNetworkAdapter: {
+			build:   c.buildVMNetworkAdapter,
+			collect: c.collectVMNetworkAdapter,
+			close:   c.closeVMNetworkAdapter,
+		},
+		subCollectorVMNUMA: {
+			build:   c.buildVMNUMA,
+			collect: c.collectVMNUMA,
+			close:   func() {},
+		},
+		subCollectorVMOwnership: {
+			build:   c.buildVMOwnership,
+			collect: c.collectVMOwnership,
+			close:   func() {},
+		},
+		subCollectorVMRemoting: {
+			build:   c.buildVMRemoting,
+			collect: c.collectVMRemoting,
+			close:   c.perfDataCollectorVMRemoting.Close,
+		},
+		subCollectorVMSecurity: {
+			build:   c.buildVMSecurity,
+			collect: c.collectVMSecurity,
+			close:   func() {},
+		},
+		subCollectorVMVCPU: {
+			build:   c.buildVMVCPU,
+			collect: c.collectVMVCPU,
+			close:   func() {},
+		},
+		subCollectorVMWorkerProcess: {
+			build:   c.buildVMWorkerProcess,
+			collect: c.collectVMWorkerProcess,
+			close:   func() {},
+		},
+		subCollectorVSwitchTeam: {
+			build:   c.buildVSwitchTeam,
+			collect: c.collectVSwitchTeam,
+			close:   func() {},
+		},
 	}
+}
 
-	buildNumber := osversion.Build()
-
-	// Result must order, to prevent test failures.
-	sort.Strings(c.config.CollectorsEnabled)
-
-	errs := make([]error, 0, len(c.config.CollectorsEnabled))
-
-	for _, name := range c.config.CollectorsEnabled {
-		if _, ok := subCollectors[name]; !ok {
-			return fmt.Errorf("unknown collector: %s", name)
-		}
-
-		if buildNumber < subCollectors[name].minBuildNumber {
-			c.logger.Warn(fmt.Sprintf(
-				"collector %s requires windows build version %d. Current build version: %d",
-				name, subCollectors[name].minBuildNumber, buildNumber,
-			))
-
-			continue
-		}
-
-		if err := subCollectors[name].build(); err != nil {
-			errs = append(errs, fmt.Errorf("failed to build %s collector: %w", name, err))
-
-			continue
-		}
-
-		c.collectorFns = append(c.collectorFns, subCollectors[name].collect)
-		c.closeFns = append(c.closeFns, subCollectors[name].close)
+// pdhOptions returns the options passed to all performance counter collectors of the hyperv collector.
+func (c *Collector) pdhOptions() []pdh.Option {
+	return []pdh.Option{
+		pdh.WithCollectorName(Name),
+		pdh.WithCounterTypes(c.config.CounterTypes),
 	}
-
-	return errors.Join(errs...)
 }
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
-	errCh := make(chan error, len(c.collectorFns))
-	errs := make([]error, 0, len(c.collectorFns))
+	errCh := make(chan error, len(c.collectorFns)+1)
+	errs := make([]error, 0, len(c.collectorFns)+1)
 
 	wg := sync.WaitGroup{}
 
-	for _, fn := range c.collectorFns {
+	for name, fn := range c.collectorFns {
 		wg.Add(1)
 
-		go func(fn func(ch chan<- prometheus.Metric) error) {
+		go func(name string, fn func(ch chan<- prometheus.Metric) error) {
 			defer wg.Done()
 
+			sampleTime := time.Now()
+
 			if err := fn(ch); err != nil {
 				errCh <- err
 			}
-		}(fn)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.subCollectorSampleTimestamp,
+				prometheus.GaugeValue,
+				float64(sampleTime.UnixMicro())/1e6,
+				name,
+			)
+		}(name, fn)
 	}
 
 	wg.Wait()
 
+	c.collectSubCollectorAvailable(ch)
+
+	if c.perfCountersRegistered != nil {
+		c.collectPerfCountersRegistered(ch)
+	}
+
+	if c.config.CounterTypes {
+		if err := c.collectCounterTypes(ch); err != nil {
+			errCh <- err
+		}
+	}
+
 	close(errCh)
 
 	for err := range errCh {
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestBuildSubCollectors(t *testing.T) {
+	t.Parallel()
+
+	c := &Collector{
+		config: Config{
+			CollectorsEnabled: []string{"corrupted", "future", "healthy"},
+		},
+		logger:       slog.New(slog.DiscardHandler),
+		collectorFns: make(map[string]func(ch chan<- prometheus.Metric) error),
+	}
+
+	var closed []string
+
+	subCollectors := func() map[string]subCollector {
+		return map[string]subCollector{
+			"corrupted": {
+				build:   func() error { return errors.New("counter set is corrupted") },
+				collect: func(chan<- prometheus.Metric) error { return nil },
+				close:   func() { closed = append(closed, "corrupted") },
+			},
+			"future": {
+				build:          func() error { return nil },
+				collect:        func(chan<- prometheus.Metric) error { return nil },
+				close:          func() { closed = append(closed, "future") },
+				minBuildNumber: math.MaxUint16,
+			},
+			"healthy": {
+				build:   func() error { return nil },
+				collect: func(chan<- prometheus.Metric) error { return nil },
+				close:   func() { closed = append(closed, "healthy") },
+			},
+		}
+	}
+
+	errs := c.buildSubCollectors(subCollectors, 20348)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "failed to build corrupted collector: counter set is corrupted")
+
+	require.Len(t, c.collectorFns, 1)
+	require.Contains(t, c.collectorFns, "healthy")
+	require.Len(t, c.closeFns, 1)
+
+	// The failed sub-collector is closed immediately, the healthy one on Close.
+	require.Equal(t, []string{"corrupted"}, closed)
+
+	ch := make(chan prometheus.Metric, 10)
+	c.collectSubCollectorAvailable(ch)
+	close(ch)
+
+	available := make(map[string]float64)
+
+	for metric := range ch {
+		var m dto.Metric
+
+		require.NoError(t, metric.Write(&m))
+		available[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+	}
+
+	require.Equal(t, map[string]float64{"corrupted": 0, "future": 0, "healthy": 1}, available)
+}
+
+func TestCloseStopsBackgroundWorkers(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	c := New(&Config{CollectorsEnabled: []string{}})
+	require.NoError(t, c.Build(slog.New(slog.DiscardHandler), nil))
+
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+
+	c.runInBackground(func(ctx context.Context) {
+		defer close(stopped)
+
+		close(started)
+		<-ctx.Done()
+	})
+
+	<-started
+
+	require.NoError(t, c.Close())
+
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("Close returned before the background worker stopped")
+	}
+}
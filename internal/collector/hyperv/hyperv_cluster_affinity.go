@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// clusterAffinityTypeAntiAffinity is the type label of anti-affinity class names.
+const clusterAffinityTypeAntiAffinity = "anti_affinity"
+
+// collectorClusterAffinity anti-affinity groups of clustered Hyper-V VMs
+type collectorClusterAffinity struct {
+	clusterAffinityGroupMIQuery    mi.Query
+	clusterAffinityResourceMIQuery mi.Query
+
+	clusterAffinityGroup   *prometheus.Desc // distinct MSCluster_ResourceGroup.AntiAffinityClassNames
+	clusterVMAffinityGroup *prometheus.Desc // MSCluster_ResourceGroup.AntiAffinityClassNames per VM
+}
+
+// msClusterResourceGroupAntiAffinity represents the anti-affinity class names of a MSCluster_ResourceGroup WMI instance.
+// The cluster places groups with a common anti-affinity class name on different nodes, if possible.
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/cluswmi/mscluster-resourcegroup
+type msClusterResourceGroupAntiAffinity struct {
+	Name                   string   `mi:"Name"`
+	AntiAffinityClassNames []string `mi:"AntiAffinityClassNames"`
+}
+
+func (c *Collector) buildClusterAffinity() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	groupMIQuery, err := mi.Select("Name", "AntiAffinityClassNames").
+		From("MSCluster_ResourceGroup").
+		WhereEqualInt("GroupType", clusterGroupTypeVirtualMachine).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	resourceMIQuery, err := mi.Select("Name", "OwnerGroup").
+		From("MSCluster_Resource").
+		WhereEqual("Type", "Virtual Machine").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.clusterAffinityGroupMIQuery = groupMIQuery
+	c.clusterAffinityResourceMIQuery = resourceMIQuery
+
+	c.clusterAffinityGroup = bdf.NewDesc(
+		Name,
+		"cluster_affinity_group",
+		"Represents an affinity group of clustered virtual machines. Always 1.",
+		[]string{"group_name", "type"},
+	)
+	c.clusterVMAffinityGroup = bdf.NewDesc(
+		Name,
+		"cluster_vm_affinity_group",
+		"Represents the membership of the virtual machine in an affinity group. Always 1.",
+		[]string{"vm", "group_name"},
+	)
+
+	var dst []msClusterResourceGroupAntiAffinity
+	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.clusterAffinityGroupMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectClusterAffinity(ch chan<- prometheus.Metric) error {
+	var groups []msClusterResourceGroupAntiAffinity
+	if err := c.miSession.Query(&groups, mi.NamespaceRootMSCluster, c.clusterAffinityGroupMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var resources []msClusterVirtualMachineResourceName
+	if err := c.miSession.Query(&resources, mi.NamespaceRootMSCluster, c.clusterAffinityResourceMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	classNames := make(map[string][]string, len(groups))
+	affinityGroups := make([]string, 0, len(groups))
+
+	for _, group := range groups {
+		classNames[group.Name] = group.AntiAffinityClassNames
+		affinityGroups = append(affinityGroups, group.AntiAffinityClassNames...)
+	}
+
+	slices.Sort(affinityGroups)
+
+	for _, affinityGroup := range slices.Compact(affinityGroups) {
+		ch <- prometheus.MustNewConstMetric(
+			c.clusterAffinityGroup,
+			prometheus.GaugeValue,
+			1,
+			affinityGroup,
+			clusterAffinityTypeAntiAffinity,
+		)
+	}
+
+	for _, resource := range resources {
+		for _, affinityGroup := range slices.Compact(slices.Sorted(slices.Values(classNames[resource.OwnerGroup]))) {
+			ch <- prometheus.MustNewConstMetric(
+				c.clusterVMAffinityGroup,
+				prometheus.GaugeValue,
+				1,
+				clusterVMName(resource.Name),
+				affinityGroup,
+			)
+		}
+	}
+
+	return nil
+}
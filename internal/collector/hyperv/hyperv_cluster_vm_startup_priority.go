@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// clusterGroupTypeVirtualMachine is the MSCluster_ResourceGroup.GroupType of virtual machine roles.
+const clusterGroupTypeVirtualMachine = 111
+
+// collectorClusterVMStartupPriority startup priority of clustered Hyper-V VMs
+type collectorClusterVMStartupPriority struct {
+	clusterVMStartupPriorityGroupMIQuery    mi.Query
+	clusterVMStartupPriorityResourceMIQuery mi.Query
+
+	clusterVMStartupPriority *prometheus.Desc // MSCluster_ResourceGroup.Priority
+}
+
+// msClusterResourceGroupPriority represents the priority of a MSCluster_ResourceGroup WMI instance
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/cluswmi/mscluster-resourcegroup
+type msClusterResourceGroupPriority struct {
+	Name     string `mi:"Name"`
+	Priority uint32 `mi:"Priority"`
+}
+
+// msClusterVirtualMachineResourceName represents the name of a virtual machine resource of the MSCluster_Resource WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/cluswmi/mscluster-resource
+type msClusterVirtualMachineResourceName struct {
+	Name       string `mi:"Name"`
+	OwnerGroup string `mi:"OwnerGroup"`
+}
+
+func (c *Collector) buildClusterVMStartupPriority() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	groupMIQuery, err := mi.Select("Name", "Priority").
+		From("MSCluster_ResourceGroup").
+		WhereEqualInt("GroupType", clusterGroupTypeVirtualMachine).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	resourceMIQuery, err := mi.Select("Name", "OwnerGroup").
+		From("MSCluster_Resource").
+		WhereEqual("Type", "Virtual Machine").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.clusterVMStartupPriorityGroupMIQuery = groupMIQuery
+	c.clusterVMStartupPriorityResourceMIQuery = resourceMIQuery
+
+	c.clusterVMStartupPriority = bdf.NewDesc(
+		Name,
+		"cluster_vm_startup_priority",
+		"Represents the startup priority of the cluster group of the virtual machine: 3000 (high), 2000 (medium), 1000 (low) or 0 (no auto start).",
+		[]string{"vm", "group"},
+	)
+
+	var dst []msClusterResourceGroupPriority
+	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.clusterVMStartupPriorityGroupMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectClusterVMStartupPriority(ch chan<- prometheus.Metric) error {
+	var groups []msClusterResourceGroupPriority
+	if err := c.miSession.Query(&groups, mi.NamespaceRootMSCluster, c.clusterVMStartupPriorityGroupMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var resources []msClusterVirtualMachineResourceName
+	if err := c.miSession.Query(&resources, mi.NamespaceRootMSCluster, c.clusterVMStartupPriorityResourceMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	priorities := make(map[string]uint32, len(groups))
+
+	for _, group := range groups {
+		priorities[group.Name] = group.Priority
+	}
+
+	for _, resource := range resources {
+		priority, ok := priorities[resource.OwnerGroup]
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.clusterVMStartupPriority,
+			prometheus.GaugeValue,
+			float64(priority),
+			clusterVMName(resource.Name),
+			resource.OwnerGroup,
+		)
+	}
+
+	return nil
+}
+
+// clusterVMName returns the VM name of a virtual machine cluster resource.
+// Failover Cluster Manager names the resource "Virtual Machine <VM name>".
+func clusterVMName(resourceName string) string {
+	return strings.TrimPrefix(resourceName, "Virtual Machine ")
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorCounterTypes exposes the PDH counter type of each performance counter used by the hyperv collector.
+// This allows to validate the unit assumptions made for each metric, e.g. PERF_COUNTER_RAWCOUNT vs PERF_ELAPSED_TIME.
+type collectorCounterTypes struct {
+	perfCounterType *prometheus.Desc
+}
+
+func (c *Collector) buildCounterTypes() {
+	c.perfCounterType = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "perf_counter_type"),
+		"The PDH counter type code of the performance counter, e.g. 65536 for PERF_COUNTER_RAWCOUNT.",
+		[]string{"object", "counter"},
+		nil,
+	)
+}
+
+// perfDataCollectors returns all performance counter collectors which are initialized.
+func (c *Collector) perfDataCollectors() []*pdh.Collector {
+	collectors := []*pdh.Collector{
+		c.perfDataCollectorDataStore,
+		c.perfDataCollectorDynamicMemoryBalancer,
+		c.perfDataCollectorDynamicMemoryVM,
+		c.perfDataCollectorHypervisorLogicalProcessor,
+		c.perfDataCollectorHypervisorRootPartition,
+		c.perfDataCollectorHypervisorRootVirtualProcessor,
+		c.perfDataCollectorHypervisorVirtualProcessor,
+		c.perfDataCollectorLegacyNetworkAdapter,
+		c.perfDataCollectorVirtualIDEController,
+		c.perfDataCollectorVirtualMachineHealthSummary,
+		c.perfDataCollectorVirtualMachineVidPartition,
+		c.perfDataCollectorVirtualNetworkAdapter,
+		c.perfDataCollectorVirtualNetworkAdapterDropReasons,
+		c.perfDataCollectorVirtualSMB,
+		c.perfDataCollectorVirtualStorageDevice,
+		c.perfDataCollectorVirtualSwitch,
+	}
+
+	initialized := make([]*pdh.Collector, 0, len(collectors))
+
+	for _, collector := range collectors {
+		if collector != nil {
+			initialized = append(initialized, collector)
+		}
+	}
+
+	return initialized
+}
+
+func (c *Collector) collectCounterTypes(ch chan<- prometheus.Metric) error {
+	for _, collector := range c.perfDataCollectors() {
+		for counter, counterType := range collector.CollectorCounterTypes() {
+			ch <- prometheus.MustNewConstMetric(
+				c.perfCounterType,
+				prometheus.GaugeValue,
+				float64(counterType),
+				collector.Object(),
+				counter,
+			)
+		}
+	}
+
+	return nil
+}
@@ -52,9 +52,9 @@ func (c *Collector) buildDynamicMemoryBalancer() error {
 	var err error
 
 	// https://learn.microsoft.com/en-us/archive/blogs/chrisavis/monitoring-dynamic-memory-in-windows-server-hyper-v-2012
-	c.perfDataCollectorDynamicMemoryBalancer, err = pdh.NewCollector[perfDataCounterValuesDynamicMemoryBalancer](c.logger, pdh.CounterTypeRaw, "Hyper-V Dynamic Memory Balancer", pdh.InstancesAll)
+	c.perfDataCollectorDynamicMemoryBalancer, err = pdh.NewCollector[perfDataCounterValuesDynamicMemoryBalancer](c.logger, pdh.CounterTypeRaw, "Hyper-V Dynamic Memory Balancer", pdh.InstancesAll, c.pdhOptions()...)
 	if err != nil {
-		return fmt.Errorf("failed to create Hyper-V Virtual Machine Health Summary collector: %w", err)
+		return fmt.Errorf("failed to create Hyper-V Dynamic Memory Balancer collector: %w", err)
 	}
 
 	c.vmDynamicMemoryBalancerAvailableMemory = prometheus.NewDesc(
@@ -99,7 +99,7 @@ func (c *Collector) collectDynamicMemoryBalancer(ch chan<- prometheus.Metric) er
 			data.Name,
 		)
 
-		if osversion.Build() >= osversion.LTSC2019 {
+		if osversion.AtLeast(osversion.LTSC2019) {
 			ch <- prometheus.MustNewConstMetric(
 				c.vmDynamicMemoryBalancerAvailableMemoryForBalancing,
 				prometheus.GaugeValue,
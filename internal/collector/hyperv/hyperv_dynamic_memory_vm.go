@@ -63,7 +63,7 @@ type perfDataCounterValuesDynamicMemoryVM struct {
 func (c *Collector) buildDynamicMemoryVM() error {
 	var err error
 
-	c.perfDataCollectorDynamicMemoryVM, err = pdh.NewCollector[perfDataCounterValuesDynamicMemoryVM](c.logger, pdh.CounterTypeRaw, "Hyper-V Dynamic Memory VM", pdh.InstancesAll)
+	c.perfDataCollectorDynamicMemoryVM, err = pdh.NewCollector[perfDataCounterValuesDynamicMemoryVM](c.logger, pdh.CounterTypeRaw, "Hyper-V Dynamic Memory VM", pdh.InstancesAll, c.pdhOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create Hyper-V Dynamic Memory VM collector: %w", err)
 	}
@@ -153,7 +153,7 @@ func (c *Collector) collectDynamicMemoryVM(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
-		if osversion.Build() >= osversion.LTSC2019 {
+		if osversion.AtLeast(osversion.LTSC2019) {
 			ch <- prometheus.MustNewConstMetric(
 				c.vmMemoryGuestAvailableMemory,
 				prometheus.GaugeValue,
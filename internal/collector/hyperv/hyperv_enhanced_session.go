@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// enhancedSessionModeStateEnabled is the Msvm_ComputerSystem.EnhancedSessionModeState value
+// reported if Enhanced Session Mode is enabled and available for the VM.
+const enhancedSessionModeStateEnabled = 2
+
+// collectorEnhancedSession Hyper-V Enhanced Session Mode state per VM
+type collectorEnhancedSession struct {
+	enhancedSessionMIQuery mi.Query
+
+	enhancedSessionEnabled *prometheus.Desc // Msvm_ComputerSystem.EnhancedSessionModeState
+}
+
+// msvmComputerSystem represents the Msvm_ComputerSystem WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-computersystem
+type msvmComputerSystem struct {
+	ElementName              string `mi:"ElementName"`
+	EnhancedSessionModeState uint16 `mi:"EnhancedSessionModeState"`
+}
+
+func (c *Collector) buildEnhancedSession() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	enhancedSessionMIQuery, err := mi.NewQuery("SELECT ElementName, EnhancedSessionModeState FROM Msvm_ComputerSystem WHERE Caption = 'Virtual Machine'")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.enhancedSessionMIQuery = enhancedSessionMIQuery
+
+	c.enhancedSessionEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "virtual_machine_enhanced_session_enabled"),
+		"Represents whether Enhanced Session Mode is enabled for the virtual machine (1 = enabled, 0 = disabled or not available).",
+		[]string{"vm"},
+		nil,
+	)
+
+	var dst []msvmComputerSystem
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.enhancedSessionMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectEnhancedSession(ch chan<- prometheus.Metric) error {
+	var dst []msvmComputerSystem
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.enhancedSessionMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	for _, vm := range dst {
+		var enabled float64
+		if vm.EnhancedSessionModeState == enhancedSessionModeStateEnabled {
+			enabled = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.enhancedSessionEnabled,
+			prometheus.GaugeValue,
+			enabled,
+			vm.ElementName,
+		)
+	}
+
+	return nil
+}
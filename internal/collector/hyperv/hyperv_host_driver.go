@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"unsafe"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+// collectorHostDriver Hyper-V host kernel driver versions
+type collectorHostDriver struct {
+	hostDriverMIQuery mi.Query
+
+	hostDriverInfo *prometheus.Desc
+}
+
+// win32SystemDriver represents the Win32_SystemDriver WMI class.
+// The class has no version property, the version is read from the driver file.
+// - https://learn.microsoft.com/en-us/windows/win32/cimwin32prov/win32-systemdriver
+type win32SystemDriver struct {
+	Name     string `mi:"Name"`
+	State    string `mi:"State"`
+	PathName string `mi:"PathName"`
+}
+
+func (c *Collector) buildHostDriver() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	hostDriverMIQuery, err := mi.Select("Name", "State", "PathName").
+		From("Win32_SystemDriver").
+		WhereIn("Name", "vmbus", "hvnetadap", "storflt", "vmstorfl").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.hostDriverMIQuery = hostDriverMIQuery
+
+	c.hostDriverInfo = bdf.NewDesc(
+		Name,
+		"driver_info",
+		"Represents the version and state of a Hyper-V kernel driver on the host.",
+		[]string{"name", "version", "state"},
+	)
+
+	var dst []win32SystemDriver
+	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.hostDriverMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectHostDriver(ch chan<- prometheus.Metric) error {
+	var dst []win32SystemDriver
+	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.hostDriverMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	for _, driver := range dst {
+		version, err := fileVersion(driverFilePath(driver.PathName))
+		if err != nil {
+			c.logger.Debug("failed to read driver file version",
+				slog.String("driver", driver.Name),
+				slog.Any("err", err),
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.hostDriverInfo,
+			prometheus.GaugeValue,
+			1,
+			driver.Name,
+			version,
+			driver.State,
+		)
+	}
+
+	return nil
+}
+
+// fileVersion returns the file version from the version resource of the file, e.g. 10.0.20348.2849.
+func fileVersion(path string) (string, error) {
+	size, err := windows.GetFileVersionInfoSize(path, nil)
+	if err != nil {
+		return "", fmt.Errorf("GetFileVersionInfoSize: %w", err)
+	}
+
+	buf := make([]byte, size)
+	if err := windows.GetFileVersionInfo(path, 0, size, unsafe.Pointer(&buf[0])); err != nil {
+		return "", fmt.Errorf("GetFileVersionInfo: %w", err)
+	}
+
+	var (
+		fixedFileInfo *windows.VS_FIXEDFILEINFO
+		length        uint32
+	)
+
+	if err := windows.VerQueryValue(unsafe.Pointer(&buf[0]), `\`, unsafe.Pointer(&fixedFileInfo), &length); err != nil {
+		return "", fmt.Errorf("VerQueryValue: %w", err)
+	}
+
+	return fmt.Sprintf("%d.%d.%d.%d",
+		fixedFileInfo.FileVersionMS>>16,
+		fixedFileInfo.FileVersionMS&0xffff,
+		fixedFileInfo.FileVersionLS>>16,
+		fixedFileInfo.FileVersionLS&0xffff,
+	), nil
+}
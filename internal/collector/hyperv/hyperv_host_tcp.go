@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorHostTCP TCP/IP network stack metrics of the Hyper-V host, e.g. for the health of the management network
+type collectorHostTCP struct {
+	perfDataCollectorHostTCPv4 *pdh.Collector
+	perfDataCollectorHostTCPv6 *pdh.Collector
+	perfDataObjectHostTCP      []perfDataCounterValuesHostTCP
+
+	hostTCPConnections           *prometheus.Desc // \TCPv4\Connections Established, \TCPv6\Connections Established
+	hostTCPRetransmittedSegments *prometheus.Desc // \TCPv4\Segments Retransmitted/sec, \TCPv6\Segments Retransmitted/sec
+	hostTCPConnectionFailures    *prometheus.Desc // \TCPv4\Connection Failures, \TCPv6\Connection Failures
+}
+
+type perfDataCounterValuesHostTCP struct {
+	ConnectionsEstablished      float64 `perfdata:"Connections Established"`
+	SegmentsRetransmittedPerSec float64 `perfdata:"Segments Retransmitted/sec"`
+	ConnectionFailures          float64 `perfdata:"Connection Failures"`
+}
+
+func (c *Collector) buildHostTCP() error {
+	var err error
+
+	c.perfDataCollectorHostTCPv4, err = pdh.NewCollector[perfDataCounterValuesHostTCP](c.logger, pdh.CounterTypeRaw, "TCPv4", nil, c.pdhOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create TCPv4 collector: %w", err)
+	}
+
+	c.perfDataCollectorHostTCPv6, err = pdh.NewCollector[perfDataCounterValuesHostTCP](c.logger, pdh.CounterTypeRaw, "TCPv6", nil, c.pdhOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create TCPv6 collector: %w", err)
+	}
+
+	c.hostTCPConnections = bdf.NewDesc(
+		Name,
+		"host_tcp_connections",
+		"Represents the number of TCP connections of the host in the ESTABLISHED or CLOSE-WAIT state.",
+		[]string{"family"},
+	)
+	c.hostTCPRetransmittedSegments = bdf.NewDesc(
+		Name,
+		"host_tcp_retransmitted_segments_total",
+		"Represents the total number of TCP segments retransmitted by the host.",
+		[]string{"family"},
+	)
+	c.hostTCPConnectionFailures = bdf.NewDesc(
+		Name,
+		"host_tcp_connection_failures_total",
+		"Represents the total number of failed TCP connection attempts of the host.",
+		[]string{"family"},
+	)
+
+	return nil
+}
+
+func (c *Collector) closeHostTCP() {
+	c.perfDataCollectorHostTCPv4.Close()
+	c.perfDataCollectorHostTCPv6.Close()
+}
+
+func (c *Collector) collectHostTCP(ch chan<- prometheus.Metric) error {
+	errs := make([]error, 0)
+
+	if err := c.collectHostTCPFamily(ch, c.perfDataCollectorHostTCPv4, "ipv4"); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect TCPv4 metrics: %w", err))
+	}
+
+	if err := c.collectHostTCPFamily(ch, c.perfDataCollectorHostTCPv6, "ipv6"); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect TCPv6 metrics: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectHostTCPFamily(ch chan<- prometheus.Metric, collector *pdh.Collector, family string) error {
+	if err := collector.Collect(&c.perfDataObjectHostTCP); err != nil {
+		return err
+	}
+
+	if len(c.perfDataObjectHostTCP) == 0 {
+		return types.ErrNoDataUnexpected
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.hostTCPConnections,
+		prometheus.GaugeValue,
+		c.perfDataObjectHostTCP[0].ConnectionsEstablished,
+		family,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.hostTCPRetransmittedSegments,
+		prometheus.CounterValue,
+		c.perfDataObjectHostTCP[0].SegmentsRetransmittedPerSec,
+		family,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.hostTCPConnectionFailures,
+		prometheus.CounterValue,
+		c.perfDataObjectHostTCP[0].ConnectionFailures,
+		family,
+	)
+
+	return nil
+}
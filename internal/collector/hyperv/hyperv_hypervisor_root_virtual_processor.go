@@ -23,6 +23,7 @@ import (
 
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -31,6 +32,10 @@ type collectorHypervisorRootVirtualProcessor struct {
 	perfDataCollectorHypervisorRootVirtualProcessor *pdh.Collector
 	perfDataObjectHypervisorRootVirtualProcessor    []perfDataCounterValuesHypervisorRootVirtualProcessor
 
+	// % Total Run Time as formatted value, since the ratio needs two samples to be calculated.
+	perfDataCollectorHypervisorRootVirtualProcessorRunTime *pdh.Collector
+	perfDataObjectHypervisorRootVirtualProcessorRunTime    []perfDataCounterValuesHypervisorRootVirtualProcessorRunTime
+
 	// \Hyper-V Hypervisor Root Virtual Processor(*)\% Guest Run Time
 	// \Hyper-V Hypervisor Root Virtual Processor(*)\% Hypervisor Run Time
 	// \Hyper-V Hypervisor Root Virtual Processor(*)\% Remote Run Time
@@ -38,6 +43,8 @@ type collectorHypervisorRootVirtualProcessor struct {
 	hypervisorRootVirtualProcessorTimeTotal              *prometheus.Desc
 	hypervisorRootVirtualProcessorTotalRunTimeTotal      *prometheus.Desc
 	hypervisorRootVirtualProcessorCPUWaitTimePerDispatch *prometheus.Desc // \Hyper-V Hypervisor Root Virtual Processor(*)\CPU Wait Time Per Dispatch
+	hypervisorRootVirtualProcessorRunTimeRatio           *prometheus.Desc // \Hyper-V Hypervisor Root Virtual Processor(*)\% Total Run Time
+	hypervisorRootPartitionCPURatio                      *prometheus.Desc // average of \Hyper-V Hypervisor Root Virtual Processor(*)\% Total Run Time
 }
 
 type perfDataCounterValuesHypervisorRootVirtualProcessor struct {
@@ -50,14 +57,25 @@ type perfDataCounterValuesHypervisorRootVirtualProcessor struct {
 	HypervisorRootVirtualProcessorCPUWaitTimePerDispatch   float64 `perfdata:"CPU Wait Time Per Dispatch"`
 }
 
+type perfDataCounterValuesHypervisorRootVirtualProcessorRunTime struct {
+	Name string
+
+	HypervisorRootVirtualProcessorTotalRunTimePercent float64 `perfdata:"% Total Run Time"`
+}
+
 func (c *Collector) buildHypervisorRootVirtualProcessor() error {
 	var err error
 
-	c.perfDataCollectorHypervisorRootVirtualProcessor, err = pdh.NewCollector[perfDataCounterValuesHypervisorRootVirtualProcessor](c.logger, pdh.CounterTypeRaw, "Hyper-V Hypervisor Root Virtual Processor", pdh.InstancesAll)
+	c.perfDataCollectorHypervisorRootVirtualProcessor, err = pdh.NewCollector[perfDataCounterValuesHypervisorRootVirtualProcessor](c.logger, pdh.CounterTypeRaw, "Hyper-V Hypervisor Root Virtual Processor", pdh.InstancesAll, c.pdhOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create Hyper-V Hypervisor Root Virtual Processor collector: %w", err)
 	}
 
+	c.perfDataCollectorHypervisorRootVirtualProcessorRunTime, err = pdh.NewCollector[perfDataCounterValuesHypervisorRootVirtualProcessorRunTime](c.logger, pdh.CounterTypeFormatted, "Hyper-V Hypervisor Root Virtual Processor", pdh.InstancesAll, c.pdhOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create Hyper-V Hypervisor Root Virtual Processor run time collector: %w", err)
+	}
+
 	c.hypervisorRootVirtualProcessorTimeTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_root_virtual_processor_time_total"),
 		"Time that processor spent in different modes (hypervisor, guest_run, guest_idle, remote)",
@@ -79,9 +97,28 @@ func (c *Collector) buildHypervisorRootVirtualProcessor() error {
 		nil,
 	)
 
+	c.hypervisorRootVirtualProcessorRunTimeRatio = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "root_virtual_processor_run_time_ratio"),
+		"Represents the ratio of time the root virtual processor of the management OS spent running, between 0.0 and 1.0.",
+		[]string{"processor"},
+		nil,
+	)
+
+	c.hypervisorRootPartitionCPURatio = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "root_partition_cpu_ratio"),
+		"Represents the ratio of time all root virtual processors of the management OS spent running, between 0.0 and 1.0.",
+		nil,
+		nil,
+	)
+
 	return nil
 }
 
+func (c *Collector) closeHypervisorRootVirtualProcessor() {
+	c.perfDataCollectorHypervisorRootVirtualProcessor.Close()
+	c.perfDataCollectorHypervisorRootVirtualProcessorRunTime.Close()
+}
+
 func (c *Collector) collectHypervisorRootVirtualProcessor(ch chan<- prometheus.Metric) error {
 	err := c.perfDataCollectorHypervisorRootVirtualProcessor.Collect(&c.perfDataObjectHypervisorRootVirtualProcessor)
 	if err != nil {
@@ -133,5 +170,37 @@ func (c *Collector) collectHypervisorRootVirtualProcessor(ch chan<- prometheus.M
 		)
 	}
 
+	err = c.perfDataCollectorHypervisorRootVirtualProcessorRunTime.Collect(&c.perfDataObjectHypervisorRootVirtualProcessorRunTime)
+	if err != nil {
+		return fmt.Errorf("failed to collect Hyper-V Hypervisor Root Virtual Processor run time metrics: %w", err)
+	}
+
+	var totalRunTimePercent float64
+
+	for _, data := range c.perfDataObjectHypervisorRootVirtualProcessorRunTime {
+		// The name format is Hv LP <core id>
+		parts := strings.Split(data.Name, " ")
+		if len(parts) != 3 {
+			return fmt.Errorf("unexpected Hyper-V Hypervisor Root Virtual Processor name format: %s", data.Name)
+		}
+
+		totalRunTimePercent += data.HypervisorRootVirtualProcessorTotalRunTimePercent
+
+		ch <- prometheus.MustNewConstMetric(
+			c.hypervisorRootVirtualProcessorRunTimeRatio,
+			prometheus.GaugeValue,
+			utils.PercentageToRatio(data.HypervisorRootVirtualProcessorTotalRunTimePercent),
+			parts[2],
+		)
+	}
+
+	if len(c.perfDataObjectHypervisorRootVirtualProcessorRunTime) > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			c.hypervisorRootPartitionCPURatio,
+			prometheus.GaugeValue,
+			utils.PercentageToRatio(totalRunTimePercent/float64(len(c.perfDataObjectHypervisorRootVirtualProcessorRunTime))),
+		)
+	}
+
 	return nil
 }
@@ -52,7 +52,7 @@ type perfDataCounterValuesHypervisorVirtualProcessor struct {
 func (c *Collector) buildHypervisorVirtualProcessor() error {
 	var err error
 
-	c.perfDataCollectorHypervisorVirtualProcessor, err = pdh.NewCollector[perfDataCounterValuesHypervisorVirtualProcessor](c.logger, pdh.CounterTypeRaw, "Hyper-V Hypervisor Virtual Processor", pdh.InstancesAll)
+	c.perfDataCollectorHypervisorVirtualProcessor, err = pdh.NewCollector[perfDataCounterValuesHypervisorVirtualProcessor](c.logger, pdh.CounterTypeRaw, "Hyper-V Hypervisor Virtual Processor", pdh.InstancesAll, c.pdhOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create Hyper-V Hypervisor Virtual Processor collector: %w", err)
 	}
@@ -87,17 +87,16 @@ func (c *Collector) collectHypervisorVirtualProcessor(ch chan<- prometheus.Metri
 
 	for _, data := range c.perfDataObjectHypervisorVirtualProcessor {
 		// The name format is <VM Name>:Hv VP <vcore id>
-		parts := strings.Split(data.Name, ":")
-		if len(parts) != 2 {
+		vmName, core, ok := splitVMInstanceName(data.Name)
+		if !ok {
 			return fmt.Errorf("unexpected format of Name in Hyper-V Hypervisor Virtual Processor: %q, expected %q", data.Name, "<VM Name>:Hv VP <vcore id>")
 		}
 
-		coreParts := strings.Split(parts[1], " ")
+		coreParts := strings.Split(core, " ")
 		if len(coreParts) != 3 {
-			return fmt.Errorf("unexpected format of core identifier in Hyper-V Hypervisor Virtual Processor: %q, expected %q", parts[1], "Hv VP <vcore id>")
+			return fmt.Errorf("unexpected format of core identifier in Hyper-V Hypervisor Virtual Processor: %q, expected %q", core, "Hv VP <vcore id>")
 		}
 
-		vmName := parts[0]
 		coreID := coreParts[2]
 
 		ch <- prometheus.MustNewConstMetric(
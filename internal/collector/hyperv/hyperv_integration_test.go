@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows && integration
+
+package hyperv_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/collector/hyperv"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// registryCollector adapts the hyperv collector to a prometheus.Collector.
+// It's an unchecked collector, since the hyperv collector doesn't describe its metrics upfront.
+type registryCollector struct {
+	collector *hyperv.Collector
+	err       error
+}
+
+func (r *registryCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (r *registryCollector) Collect(ch chan<- prometheus.Metric) {
+	r.err = r.collector.Collect(ch)
+}
+
+func TestCollectorIntegration(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	miApp, err := mi.ApplicationInitialize()
+	require.NoError(t, err)
+
+	miSession, err := miApp.NewSession(nil)
+	require.NoError(t, err)
+
+	// The host-level sub-collectors are available on every Hyper-V host, even without any VM.
+	c := hyperv.New(&hyperv.Config{
+		CollectorsEnabled: []string{
+			"dynamic_memory_balancer",
+			"hypervisor_logical_processor",
+			"hypervisor_root_partition",
+			"virtual_machine_health_summary",
+		},
+	})
+
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+		require.NoError(t, miSession.Close())
+		require.NoError(t, miApp.Close())
+	})
+
+	err = c.Build(logger, miSession)
+	if errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) {
+		t.Skip("Hyper-V is not installed on this system")
+	}
+
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	collector := &registryCollector{collector: c}
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	metricFamilies, err := registry.Gather()
+	require.NoError(t, err)
+	require.NoError(t, collector.err)
+
+	names := make([]string, 0, len(metricFamilies))
+	for _, metricFamily := range metricFamilies {
+		names = append(names, metricFamily.GetName())
+	}
+
+	for _, name := range []string{
+		"windows_hyperv_dynamic_memory_balancer_available_memory_bytes",
+		"windows_hyperv_hypervisor_logical_processor_time_total",
+		"windows_hyperv_root_partition_address_spaces",
+		"windows_hyperv_virtual_machine_health_total_count",
+	} {
+		require.Contains(t, names, name)
+	}
+}
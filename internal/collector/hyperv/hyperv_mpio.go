@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorMpio multipath I/O paths per MPIO disk
+type collectorMpio struct {
+	mpioMIQuery mi.Query
+
+	mpioDiskPathCount *prometheus.Desc // MPIO_GET_DESCRIPTOR.NumberPdos
+}
+
+// mpioGetDescriptor represents the MPIO_GET_DESCRIPTOR WMI class of the MPIO driver.
+// There is one instance for each MPIO disk and NumberPdos is the number of paths to the disk.
+// MSFT_MpioDiskInfo/MPIO_DISK_INFO report the paths in an array of embedded objects,
+// which is not supported by the mi package.
+type mpioGetDescriptor struct {
+	DeviceName string `mi:"DeviceName"`
+	NumberPdos uint32 `mi:"NumberPdos"`
+}
+
+func (c *Collector) buildMpio() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	mpioMIQuery, err := mi.Select("DeviceName", "NumberPdos").From("MPIO_GET_DESCRIPTOR").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.mpioMIQuery = mpioMIQuery
+
+	c.mpioDiskPathCount = bdf.NewDesc(
+		Name,
+		"mpio_disk_path_count",
+		"Represents the number of paths to the MPIO disk.",
+		[]string{"disk_id"},
+	)
+
+	var dst []mpioGetDescriptor
+	if err := c.miSession.Query(&dst, mi.NamespaceRootWMI, c.mpioMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectMpio(ch chan<- prometheus.Metric) error {
+	var disks []mpioGetDescriptor
+	if err := c.miSession.Query(&disks, mi.NamespaceRootWMI, c.mpioMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	for _, disk := range disks {
+		ch <- prometheus.MustNewConstMetric(
+			c.mpioDiskPathCount,
+			prometheus.GaugeValue,
+			float64(disk.NumberPdos),
+			disk.DeviceName,
+		)
+	}
+
+	return nil
+}
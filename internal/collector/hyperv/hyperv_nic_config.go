@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// nicConfigRefreshInterval is the interval in which the physical network adapter settings are refreshed.
+// The settings only change on reconfiguration or driver updates.
+const nicConfigRefreshInterval = 5 * time.Minute
+
+// collectorNicConfig physical network adapter settings relevant for Hyper-V and SMB performance
+type collectorNicConfig struct {
+	nicConfigAdapterMIQuery  mi.Query
+	nicConfigRssMIQuery      mi.Query
+	nicConfigRscMIQuery      mi.Query
+	nicConfigVmqMIQuery      mi.Query
+	nicConfigVmqQueueMIQuery mi.Query
+
+	nicConfigMu          sync.Mutex
+	nicConfigCache       []nicConfig
+	nicConfigLastRefresh time.Time
+
+	nicMTU        *prometheus.Desc // MSFT_NetAdapter.MtuSize
+	nicRssEnabled *prometheus.Desc // MSFT_NetAdapterRssSettingData.Enabled
+	nicRscEnabled *prometheus.Desc // MSFT_NetAdapterRscSettingData.IPv4Enabled, IPv6Enabled
+	nicVmqEnabled *prometheus.Desc // MSFT_NetAdapterVmqSettingData.Enabled
+	nicVmqQueues  *prometheus.Desc // count of MSFT_NetAdapterVmqQueueSettingData per adapter
+}
+
+type nicConfig struct {
+	adapter   string
+	mtu       float64
+	rss       *bool
+	rscIPv4   *bool
+	rscIPv6   *bool
+	vmq       *bool
+	vmqQueues float64
+}
+
+// msftNetAdapterMtu represents the MSFT_NetAdapter WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/fwp/wmi/netadaptercimprov/msft-netadapter
+type msftNetAdapterMtu struct {
+	Name    string `mi:"Name"`
+	MtuSize uint32 `mi:"MtuSize"`
+}
+
+// msftNetAdapterRssSettingData represents the MSFT_NetAdapterRssSettingData WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/fwp/wmi/netadaptercimprov/msft-netadapterrsssettingdata
+type msftNetAdapterRssSettingData struct {
+	Name    string `mi:"Name"`
+	Enabled bool   `mi:"Enabled"`
+}
+
+// msftNetAdapterRscSettingData represents the MSFT_NetAdapterRscSettingData WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/fwp/wmi/netadaptercimprov/msft-netadapterrscsettingdata
+type msftNetAdapterRscSettingData struct {
+	Name        string `mi:"Name"`
+	IPv4Enabled bool   `mi:"IPv4Enabled"`
+	IPv6Enabled bool   `mi:"IPv6Enabled"`
+}
+
+// msftNetAdapterVmqSettingData represents the MSFT_NetAdapterVmqSettingData WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/fwp/wmi/netadaptercimprov/msft-netadaptervmqsettingdata
+type msftNetAdapterVmqSettingData struct {
+	Name    string `mi:"Name"`
+	Enabled bool   `mi:"Enabled"`
+}
+
+// msftNetAdapterVmqQueueSettingData represents the MSFT_NetAdapterVmqQueueSettingData WMI class.
+// There is one instance for each VMQ queue currently allocated on an adapter.
+// - https://learn.microsoft.com/en-us/windows/win32/fwp/wmi/netadaptercimprov/msft-netadaptervmqqueuesettingdata
+type msftNetAdapterVmqQueueSettingData struct {
+	Name string `mi:"Name"`
+}
+
+func (c *Collector) buildNicConfig() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	// ConnectorPresent is set for physical network adapters only, see Get-NetAdapter -Physical.
+	c.nicConfigAdapterMIQuery, err = mi.Select("Name", "MtuSize").
+		From("MSFT_NetAdapter").
+		WhereEqualBool("ConnectorPresent", true).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.nicConfigRssMIQuery, err = mi.Select("Name", "Enabled").From("MSFT_NetAdapterRssSettingData").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.nicConfigRscMIQuery, err = mi.Select("Name", "IPv4Enabled", "IPv6Enabled").From("MSFT_NetAdapterRscSettingData").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.nicConfigVmqMIQuery, err = mi.Select("Name", "Enabled").From("MSFT_NetAdapterVmqSettingData").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.nicConfigVmqQueueMIQuery, err = mi.Select("Name").From("MSFT_NetAdapterVmqQueueSettingData").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.nicMTU = bdf.NewDesc(
+		Name,
+		"nic_mtu_bytes",
+		"Represents the effective MTU of the physical network adapter.",
+		[]string{"adapter"},
+	)
+	c.nicRssEnabled = bdf.NewDesc(
+		Name,
+		"nic_rss_enabled",
+		"Represents whether receive side scaling (RSS) is enabled on the physical network adapter.",
+		[]string{"adapter"},
+	)
+	c.nicRscEnabled = bdf.NewDesc(
+		Name,
+		"nic_rsc_enabled",
+		"Represents whether receive segment coalescing (RSC) is enabled on the physical network adapter.",
+		[]string{"adapter", "ip_version"},
+	)
+	c.nicVmqEnabled = bdf.NewDesc(
+		Name,
+		"nic_vmq_enabled",
+		"Represents whether virtual machine queues (VMQ) are enabled on the physical network adapter.",
+		[]string{"adapter"},
+	)
+	c.nicVmqQueues = bdf.NewDesc(
+		Name,
+		"nic_vmq_queues",
+		"Represents the number of virtual machine queues (VMQ) currently allocated on the physical network adapter.",
+		[]string{"adapter"},
+	)
+
+	var dst []msftNetAdapterMtu
+	if err := c.miSession.Query(&dst, mi.NamespaceRootStandardCimv2, c.nicConfigAdapterMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectNicConfig(ch chan<- prometheus.Metric) error {
+	c.nicConfigMu.Lock()
+	defer c.nicConfigMu.Unlock()
+
+	if time.Since(c.nicConfigLastRefresh) >= nicConfigRefreshInterval {
+		adapters, err := c.queryNicConfig()
+		if err != nil {
+			if c.nicConfigCache == nil {
+				return err
+			}
+
+			c.logger.Warn("failed to refresh physical network adapter settings, using last known settings",
+				slog.Any("err", err),
+			)
+		} else {
+			c.nicConfigCache = adapters
+			c.nicConfigLastRefresh = time.Now()
+		}
+	}
+
+	for _, adapter := range c.nicConfigCache {
+		ch <- prometheus.MustNewConstMetric(
+			c.nicMTU,
+			prometheus.GaugeValue,
+			adapter.mtu,
+			adapter.adapter,
+		)
+
+		// Adapters without RSS, RSC or VMQ support have no setting data instance
+		// and the respective metrics are omitted.
+		if adapter.rss != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.nicRssEnabled,
+				prometheus.GaugeValue,
+				utils.BoolToFloat(*adapter.rss),
+				adapter.adapter,
+			)
+		}
+
+		if adapter.rscIPv4 != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.nicRscEnabled,
+				prometheus.GaugeValue,
+				utils.BoolToFloat(*adapter.rscIPv4),
+				adapter.adapter,
+				"ipv4",
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.nicRscEnabled,
+				prometheus.GaugeValue,
+				utils.BoolToFloat(*adapter.rscIPv6),
+				adapter.adapter,
+				"ipv6",
+			)
+		}
+
+		if adapter.vmq != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.nicVmqEnabled,
+				prometheus.GaugeValue,
+				utils.BoolToFloat(*adapter.vmq),
+				adapter.adapter,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.nicVmqQueues,
+				prometheus.GaugeValue,
+				adapter.vmqQueues,
+				adapter.adapter,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) queryNicConfig() ([]nicConfig, error) {
+	var adapters []msftNetAdapterMtu
+	if err := c.miSession.Query(&adapters, mi.NamespaceRootStandardCimv2, c.nicConfigAdapterMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var rssSettings []msftNetAdapterRssSettingData
+	if err := c.miSession.Query(&rssSettings, mi.NamespaceRootStandardCimv2, c.nicConfigRssMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var rscSettings []msftNetAdapterRscSettingData
+	if err := c.miSession.Query(&rscSettings, mi.NamespaceRootStandardCimv2, c.nicConfigRscMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var vmqSettings []msftNetAdapterVmqSettingData
+	if err := c.miSession.Query(&vmqSettings, mi.NamespaceRootStandardCimv2, c.nicConfigVmqMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var vmqQueues []msftNetAdapterVmqQueueSettingData
+	if err := c.miSession.Query(&vmqQueues, mi.NamespaceRootStandardCimv2, c.nicConfigVmqQueueMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	configs := make([]nicConfig, 0, len(adapters))
+	index := make(map[string]int, len(adapters))
+
+	for _, adapter := range adapters {
+		index[adapter.Name] = len(configs)
+		configs = append(configs, nicConfig{
+			adapter: adapter.Name,
+			mtu:     float64(adapter.MtuSize),
+		})
+	}
+
+	for _, setting := range rssSettings {
+		if i, ok := index[setting.Name]; ok {
+			configs[i].rss = &setting.Enabled
+		}
+	}
+
+	for _, setting := range rscSettings {
+		if i, ok := index[setting.Name]; ok {
+			configs[i].rscIPv4 = &setting.IPv4Enabled
+			configs[i].rscIPv6 = &setting.IPv6Enabled
+		}
+	}
+
+	for _, setting := range vmqSettings {
+		if i, ok := index[setting.Name]; ok {
+			configs[i].vmq = &setting.Enabled
+		}
+	}
+
+	for _, queue := range vmqQueues {
+		if i, ok := index[queue.Name]; ok {
+			configs[i].vmqQueues++
+		}
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// perfCountersRegisteredCounterPath is a counter of the Hyper-V Hypervisor object, which is present on every Hyper-V host.
+	perfCountersRegisteredCounterPath = `\Hyper-V Hypervisor\Logical Processors`
+	perfCountersRegisteredInterval    = 5 * time.Minute
+)
+
+// collectorPerfCountersRegistered detects Hyper-V performance counters which are no longer registered,
+// e.g. after a Windows update. In that case, the performance counter based metrics are missing and
+// the counters have to be rebuilt with lodctr /R.
+type collectorPerfCountersRegistered struct {
+	perfCountersRegisteredValue atomic.Bool
+
+	perfCountersRegistered *prometheus.Desc
+}
+
+func (c *Collector) buildPerfCountersRegistered() {
+	c.perfCountersRegistered = bdf.NewDesc(
+		Name,
+		"perf_counters_registered",
+		"1 if the Hyper-V performance counters are registered, 0 otherwise. If 0, the counters can be rebuilt with lodctr /R.",
+		nil,
+	)
+
+	c.checkPerfCountersRegistered()
+
+	if !c.perfCountersRegisteredValue.Load() {
+		c.logger.Warn("Hyper-V performance counters are not registered. Run lodctr /R to rebuild the performance counters.")
+	}
+
+	c.runInBackground(c.runPerfCountersRegisteredChecker)
+}
+
+func (c *Collector) runPerfCountersRegisteredChecker(ctx context.Context) {
+	ticker := time.NewTicker(perfCountersRegisteredInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkPerfCountersRegistered()
+		}
+	}
+}
+
+func (c *Collector) checkPerfCountersRegistered() {
+	registered, err := pdh.CounterExists(perfCountersRegisteredCounterPath)
+	if err != nil {
+		c.logger.Debug("failed to check if the Hyper-V performance counters are registered",
+			slog.Any("err", err),
+		)
+
+		return
+	}
+
+	if c.perfCountersRegisteredValue.Swap(registered) && !registered {
+		c.logger.Warn("Hyper-V performance counters are no longer registered. Run lodctr /R to rebuild the performance counters.")
+	}
+}
+
+func (c *Collector) collectPerfCountersRegistered(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		c.perfCountersRegistered,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(c.perfCountersRegisteredValue.Load()),
+	)
+}
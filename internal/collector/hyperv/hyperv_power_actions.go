@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// powerActionsPollInterval is the interval in which the VM states are polled to detect power actions.
+const powerActionsPollInterval = 5 * time.Second
+
+// Msvm_ComputerSystem.EnabledState values
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-computersystem
+const (
+	vmEnabledStateRunning = 2
+	vmEnabledStateOff     = 3
+	vmEnabledStateSaved   = 6
+	vmEnabledStatePaused  = 9
+)
+
+// collectorPowerActions Hyper-V VM power actions since exporter start
+type collectorPowerActions struct {
+	powerActionsMIQuery mi.Query
+
+	// powerActionsMu guards the fields below, which are updated by the poller goroutine.
+	// Both maps are keyed by the VM ID, since VM names are not unique and can change.
+	powerActionsMu     sync.Mutex
+	powerActionsStates map[string]uint16
+	powerActionsCounts map[string]*vmPowerActionCounts
+
+	powerActionsStart *prometheus.Desc
+	powerActionsStop  *prometheus.Desc
+	powerActionsSave  *prometheus.Desc
+	powerActionsPause *prometheus.Desc
+}
+
+type vmPowerActionCounts struct {
+	vmName string
+
+	start float64
+	stop  float64
+	save  float64
+	pause float64
+}
+
+// msvmComputerSystemState represents the state of a Msvm_ComputerSystem WMI instance
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-computersystem
+type msvmComputerSystemState struct {
+	Name         string `mi:"Name"`
+	ElementName  string `mi:"ElementName"`
+	EnabledState uint16 `mi:"EnabledState"`
+}
+
+func (c *Collector) buildPowerActions() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	powerActionsMIQuery, err := mi.Select("Name", "ElementName", "EnabledState").
+		From("Msvm_ComputerSystem").
+		WhereEqual("Caption", "Virtual Machine").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.powerActionsMIQuery = powerActionsMIQuery
+	c.powerActionsStates = make(map[string]uint16)
+	c.powerActionsCounts = make(map[string]*vmPowerActionCounts)
+
+	c.powerActionsStart = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "virtual_machine_start_total"),
+		"Represents the number of times the virtual machine was started or restored from a saved state since the exporter started.",
+		[]string{"vm"},
+		nil,
+	)
+	c.powerActionsStop = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "virtual_machine_stop_total"),
+		"Represents the number of times the virtual machine was turned off since the exporter started.",
+		[]string{"vm"},
+		nil,
+	)
+	c.powerActionsSave = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "virtual_machine_save_total"),
+		"Represents the number of times the virtual machine was saved since the exporter started.",
+		[]string{"vm"},
+		nil,
+	)
+	c.powerActionsPause = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "virtual_machine_pause_total"),
+		"Represents the number of times the virtual machine was paused since the exporter started.",
+		[]string{"vm"},
+		nil,
+	)
+
+	// The first poll records the initial states, which are not counted as power actions.
+	if err := c.pollPowerActions(); err != nil {
+		return err
+	}
+
+	c.runInBackground(c.runPowerActionsPoller)
+
+	return nil
+}
+
+func (c *Collector) runPowerActionsPoller(ctx context.Context) {
+	ticker := time.NewTicker(powerActionsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.pollPowerActions(); err != nil {
+				c.logger.Debug("failed to poll Hyper-V virtual machine states",
+					slog.Any("err", err),
+				)
+			}
+		}
+	}
+}
+
+// pollPowerActions queries the current VM states and counts the power actions since the last poll.
+// VMs which no longer exist are removed.
+func (c *Collector) pollPowerActions() error {
+	var dst []msvmComputerSystemState
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.powerActionsMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	c.powerActionsMu.Lock()
+	defer c.powerActionsMu.Unlock()
+
+	seen := make(map[string]struct{}, len(dst))
+
+	for _, vm := range dst {
+		seen[vm.Name] = struct{}{}
+
+		counts, ok := c.powerActionsCounts[vm.Name]
+		if !ok {
+			counts = &vmPowerActionCounts{}
+			c.powerActionsCounts[vm.Name] = counts
+		}
+
+		counts.vmName = vm.ElementName
+
+		previousState, ok := c.powerActionsStates[vm.Name]
+		c.powerActionsStates[vm.Name] = vm.EnabledState
+
+		if !ok || previousState == vm.EnabledState {
+			continue
+		}
+
+		switch vm.EnabledState {
+		case vmEnabledStateRunning:
+			// Resuming a paused VM is not a start.
+			if previousState != vmEnabledStatePaused {
+				counts.start++
+			}
+		case vmEnabledStateOff:
+			counts.stop++
+		case vmEnabledStateSaved:
+			counts.save++
+		case vmEnabledStatePaused:
+			counts.pause++
+		}
+	}
+
+	for vmID := range c.powerActionsCounts {
+		if _, ok := seen[vmID]; !ok {
+			delete(c.powerActionsCounts, vmID)
+			delete(c.powerActionsStates, vmID)
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) collectPowerActions(ch chan<- prometheus.Metric) error {
+	c.powerActionsMu.Lock()
+	defer c.powerActionsMu.Unlock()
+
+	for _, counts := range c.powerActionsCounts {
+		ch <- prometheus.MustNewConstMetric(
+			c.powerActionsStart,
+			prometheus.CounterValue,
+			counts.start,
+			counts.vmName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.powerActionsStop,
+			prometheus.CounterValue,
+			counts.stop,
+			counts.vmName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.powerActionsSave,
+			prometheus.CounterValue,
+			counts.save,
+			counts.vmName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.powerActionsPause,
+			prometheus.CounterValue,
+			counts.pause,
+			counts.vmName,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorReservationUtilization Hyper-V VM resource usage relative to the configured reservation
+type collectorReservationUtilization struct {
+	reservationComputerSystemMIQuery mi.Query
+	reservationProcessorMIQuery      mi.Query
+	reservationMemoryMIQuery         mi.Query
+
+	perfDataCollectorReservationVirtualProcessor *pdh.Collector
+	perfDataObjectReservationVirtualProcessor    []perfDataCounterValuesReservationVirtualProcessor
+	perfDataCollectorReservationMemory           *pdh.Collector
+	perfDataObjectReservationMemory              []perfDataCounterValuesReservationMemory
+
+	vmCPUReservationUtilizationRatio    *prometheus.Desc
+	vmMemoryReservationUtilizationRatio *prometheus.Desc
+}
+
+type perfDataCounterValuesReservationVirtualProcessor struct {
+	Name string
+
+	GuestRunTimePercent float64 `perfdata:"% Guest Run Time"`
+}
+
+type perfDataCounterValuesReservationMemory struct {
+	Name string
+
+	PhysicalMemory float64 `perfdata:"Physical Memory"`
+}
+
+// msvmComputerSystemID represents the ID and name of a Msvm_ComputerSystem WMI instance.
+// Name is the GUID of the VM.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-computersystem
+type msvmComputerSystemID struct {
+	Name        string `mi:"Name"`
+	ElementName string `mi:"ElementName"`
+}
+
+// msvmProcessorSettingData represents the Msvm_ProcessorSettingData WMI class.
+// Reservation is in 1/1000 of a percent of the VM's virtual processors.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-processorsettingdata
+type msvmProcessorSettingData struct {
+	InstanceID  string `mi:"InstanceID"`
+	Reservation uint64 `mi:"Reservation"`
+}
+
+// msvmMemorySettingData represents the Msvm_MemorySettingData WMI class.
+// Reservation is in megabytes.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-memorysettingdata
+type msvmMemorySettingData struct {
+	InstanceID  string `mi:"InstanceID"`
+	Reservation uint64 `mi:"Reservation"`
+}
+
+func (c *Collector) buildReservationUtilization() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	c.reservationComputerSystemMIQuery, err = mi.NewQuery("SELECT Name, ElementName FROM Msvm_ComputerSystem WHERE Caption = 'Virtual Machine'")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.reservationProcessorMIQuery, err = mi.NewQuery("SELECT InstanceID, Reservation FROM Msvm_ProcessorSettingData")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.reservationMemoryMIQuery, err = mi.NewQuery("SELECT InstanceID, Reservation FROM Msvm_MemorySettingData")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.perfDataCollectorReservationVirtualProcessor, err = pdh.NewCollector[perfDataCounterValuesReservationVirtualProcessor](c.logger, pdh.CounterTypeFormatted, "Hyper-V Hypervisor Virtual Processor", pdh.InstancesAll, c.pdhOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create Hyper-V Hypervisor Virtual Processor collector: %w", err)
+	}
+
+	c.perfDataCollectorReservationMemory, err = pdh.NewCollector[perfDataCounterValuesReservationMemory](c.logger, pdh.CounterTypeRaw, "Hyper-V Dynamic Memory VM", pdh.InstancesAll, c.pdhOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create Hyper-V Dynamic Memory VM collector: %w", err)
+	}
+
+	c.vmCPUReservationUtilizationRatio = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "vm_cpu_reservation_utilization_ratio"),
+		"Represents the average guest run time of the virtual processors relative to the CPU reservation of the virtual machine. Values above 1 indicate usage beyond the reservation.",
+		[]string{"vm"},
+		nil,
+	)
+	c.vmMemoryReservationUtilizationRatio = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "vm_memory_reservation_utilization_ratio"),
+		"Represents the physical memory assigned to the virtual machine relative to its memory reservation. Values above 1 indicate usage beyond the reservation.",
+		[]string{"vm"},
+		nil,
+	)
+
+	var dst []msvmComputerSystemID
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.reservationComputerSystemMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) closeReservationUtilization() {
+	c.perfDataCollectorReservationVirtualProcessor.Close()
+	c.perfDataCollectorReservationMemory.Close()
+}
+
+func (c *Collector) collectReservationUtilization(ch chan<- prometheus.Metric) error {
+	var vms []msvmComputerSystemID
+	if err := c.miSession.Query(&vms, mi.NamespaceRootVirtualizationV2, c.reservationComputerSystemMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var processorSettings []msvmProcessorSettingData
+	if err := c.miSession.Query(&processorSettings, mi.NamespaceRootVirtualizationV2, c.reservationProcessorMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var memorySettings []msvmMemorySettingData
+	if err := c.miSession.Query(&memorySettings, mi.NamespaceRootVirtualizationV2, c.reservationMemoryMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	if err := c.perfDataCollectorReservationVirtualProcessor.Collect(&c.perfDataObjectReservationVirtualProcessor); err != nil {
+		return fmt.Errorf("failed to collect Hyper-V Hypervisor Virtual Processor metrics: %w", err)
+	}
+
+	if err := c.perfDataCollectorReservationMemory.Collect(&c.perfDataObjectReservationMemory); err != nil {
+		return fmt.Errorf("failed to collect Hyper-V Dynamic Memory VM metrics: %w", err)
+	}
+
+	vmNames := vmNamesByID(vms)
+
+	cpuReservation := make(map[string]float64, len(processorSettings))
+
+	for _, setting := range processorSettings {
+		if vmName, ok := vmNameFromInstanceID(vmNames, setting.InstanceID); ok {
+			// Reservation is in 1/1000 of a percent.
+			cpuReservation[vmName] = float64(setting.Reservation) / 1000
+		}
+	}
+
+	memoryReservation := make(map[string]float64, len(memorySettings))
+
+	for _, setting := range memorySettings {
+		if vmName, ok := vmNameFromInstanceID(vmNames, setting.InstanceID); ok {
+			memoryReservation[vmName] = float64(setting.Reservation)
+		}
+	}
+
+	guestRunTimeSum := make(map[string]float64)
+	virtualProcessors := make(map[string]float64)
+
+	for _, data := range c.perfDataObjectReservationVirtualProcessor {
+		vmName, _, ok := splitVMInstanceName(data.Name)
+		if !ok {
+			continue
+		}
+
+		guestRunTimeSum[vmName] += data.GuestRunTimePercent
+		virtualProcessors[vmName]++
+	}
+
+	for vmName, reservation := range cpuReservation {
+		if reservation == 0 || virtualProcessors[vmName] == 0 {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmCPUReservationUtilizationRatio,
+			prometheus.GaugeValue,
+			guestRunTimeSum[vmName]/virtualProcessors[vmName]/reservation,
+			vmName,
+		)
+	}
+
+	for _, data := range c.perfDataObjectReservationMemory {
+		reservation := memoryReservation[data.Name]
+		if reservation == 0 {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmMemoryReservationUtilizationRatio,
+			prometheus.GaugeValue,
+			data.PhysicalMemory/reservation,
+			data.Name,
+		)
+	}
+
+	return nil
+}
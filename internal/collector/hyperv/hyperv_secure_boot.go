@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorSecureBoot Hyper-V generation 2 VM UEFI secure boot settings
+type collectorSecureBoot struct {
+	secureBootMIQuery mi.Query
+
+	secureBootEnabled  *prometheus.Desc // Msvm_VirtualSystemSettingData.SecureBootEnabled
+	secureBootTemplate *prometheus.Desc // Msvm_VirtualSystemSettingData.SecureBootTemplateId
+}
+
+// msvmVirtualSystemSecureBootSettingData represents the secure boot settings of the Msvm_VirtualSystemSettingData WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-virtualsystemsettingdata
+type msvmVirtualSystemSecureBootSettingData struct {
+	ElementName          string `mi:"ElementName"`
+	SecureBootEnabled    bool   `mi:"SecureBootEnabled"`
+	SecureBootTemplateID string `mi:"SecureBootTemplateId"`
+}
+
+func (c *Collector) buildSecureBoot() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	// UEFI secure boot is only available for generation 2 VMs.
+	secureBootMIQuery, err := mi.Select("ElementName", "SecureBootEnabled", "SecureBootTemplateId").
+		From("Msvm_VirtualSystemSettingData").
+		WhereEqual("VirtualSystemType", "Microsoft:Hyper-V:System:Realized").
+		WhereEqual("VirtualSystemSubType", "Microsoft:Hyper-V:SubType:2").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.secureBootMIQuery = secureBootMIQuery
+
+	c.secureBootEnabled = bdf.NewDesc(
+		Name,
+		"virtual_machine_uefi_secure_boot_enabled",
+		"Represents whether UEFI secure boot is enabled for the generation 2 virtual machine.",
+		[]string{"vm"},
+	)
+	c.secureBootTemplate = bdf.NewDesc(
+		Name,
+		"virtual_machine_uefi_secure_boot_template",
+		"Represents the ID of the UEFI secure boot template of the generation 2 virtual machine.",
+		[]string{"vm", "template"},
+	)
+
+	var dst []msvmVirtualSystemSecureBootSettingData
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.secureBootMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectSecureBoot(ch chan<- prometheus.Metric) error {
+	var dst []msvmVirtualSystemSecureBootSettingData
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.secureBootMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	for _, vm := range dst {
+		enabled := 0.0
+		if vm.SecureBootEnabled {
+			enabled = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.secureBootEnabled,
+			prometheus.GaugeValue,
+			enabled,
+			vm.ElementName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.secureBootTemplate,
+			prometheus.GaugeValue,
+			1,
+			vm.ElementName,
+			vm.SecureBootTemplateID,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorSriov SR-IOV virtual function usage per physical adapter
+type collectorSriov struct {
+	sriovMIQuery   mi.Query
+	sriovVFMIQuery mi.Query
+
+	sriovVFTotal     *prometheus.Desc // MSFT_NetAdapterSriovSettingData.NumVFs
+	sriovVFAllocated *prometheus.Desc // count of MSFT_NetAdapterSriovVfSettingData per physical function
+}
+
+// msftNetAdapterSriovSettingData represents the MSFT_NetAdapterSriovSettingData WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/legacy/hh968170(v=vs.85)
+type msftNetAdapterSriovSettingData struct {
+	Name   string `mi:"Name"`
+	NumVFs uint32 `mi:"NumVFs"`
+}
+
+// msftNetAdapterSriovVfSettingData represents the MSFT_NetAdapterSriovVfSettingData WMI class.
+// There is one instance for each virtual function currently allocated on a physical function.
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/legacy/hh968171(v=vs.85)
+type msftNetAdapterSriovVfSettingData struct {
+	Name string `mi:"Name"`
+}
+
+func (c *Collector) buildSriov() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	sriovMIQuery, err := mi.NewQuery("SELECT Name, NumVFs FROM MSFT_NetAdapterSriovSettingData")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	sriovVFMIQuery, err := mi.NewQuery("SELECT Name FROM MSFT_NetAdapterSriovVfSettingData")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.sriovMIQuery = sriovMIQuery
+	c.sriovVFMIQuery = sriovVFMIQuery
+
+	c.sriovVFTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "sriov_vf_total"),
+		"Represents the number of SR-IOV virtual functions available on the physical adapter.",
+		[]string{"adapter"},
+		nil,
+	)
+	c.sriovVFAllocated = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "sriov_vf_allocated"),
+		"Represents the number of SR-IOV virtual functions currently allocated on the physical adapter.",
+		[]string{"adapter"},
+		nil,
+	)
+
+	var dst []msftNetAdapterSriovSettingData
+	if err := c.miSession.Query(&dst, mi.NamespaceRootStandardCimv2, c.sriovMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectSriov(ch chan<- prometheus.Metric) error {
+	var adapters []msftNetAdapterSriovSettingData
+	if err := c.miSession.Query(&adapters, mi.NamespaceRootStandardCimv2, c.sriovMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var vfs []msftNetAdapterSriovVfSettingData
+	if err := c.miSession.Query(&vfs, mi.NamespaceRootStandardCimv2, c.sriovVFMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	allocated := make(map[string]float64, len(adapters))
+
+	for _, vf := range vfs {
+		allocated[vf.Name]++
+	}
+
+	for _, adapter := range adapters {
+		ch <- prometheus.MustNewConstMetric(
+			c.sriovVFTotal,
+			prometheus.GaugeValue,
+			float64(adapter.NumVFs),
+			adapter.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.sriovVFAllocated,
+			prometheus.GaugeValue,
+			allocated[adapter.Name],
+			adapter.Name,
+		)
+	}
+
+	return nil
+}
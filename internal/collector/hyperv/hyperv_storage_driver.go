@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorStorageDriver Hyper-V host storage controller driver versions
+type collectorStorageDriver struct {
+	storageDriverMIQuery mi.Query
+
+	storageDriverInfo *prometheus.Desc
+}
+
+// win32PnPSignedDriver represents the Win32_PnPSignedDriver WMI class.
+// DriverDate is a DATETIME property, which is not supported by the mi package.
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/legacy/aa394354(v=vs.85)
+type win32PnPSignedDriver struct {
+	DeviceName    string `mi:"DeviceName"`
+	DriverVersion string `mi:"DriverVersion"`
+}
+
+func (c *Collector) buildStorageDriver() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	storageDriverMIQuery, err := mi.NewQuery("SELECT DeviceName, DriverVersion FROM Win32_PnPSignedDriver WHERE DeviceClass = 'DiskDrive' OR DeviceClass = 'SCSIAdapter'")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.storageDriverMIQuery = storageDriverMIQuery
+
+	c.storageDriverInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "storage_driver_info"),
+		"Represents the driver version of a disk drive or storage controller on the Hyper-V host.",
+		[]string{"device_name", "driver_version"},
+		nil,
+	)
+
+	var dst []win32PnPSignedDriver
+	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.storageDriverMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectStorageDriver(ch chan<- prometheus.Metric) error {
+	var dst []win32PnPSignedDriver
+	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.storageDriverMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	// Identical devices, e.g. multiple disks of the same model, result in duplicate label sets.
+	seen := make(map[win32PnPSignedDriver]struct{}, len(dst))
+
+	for _, driver := range dst {
+		if _, ok := seen[driver]; ok {
+			continue
+		}
+
+		seen[driver] = struct{}{}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.storageDriverInfo,
+			prometheus.GaugeValue,
+			1,
+			driver.DeviceName,
+			driver.DriverVersion,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorStorageQoS Storage QoS policy IOPS budgets and current usage
+type collectorStorageQoS struct {
+	storageQoSPolicyMIQuery mi.Query
+	storageQoSFlowMIQuery   mi.Query
+
+	storageQoSPolicyIOPSBudget  *prometheus.Desc // MSFT_StorageQoSPolicy.MaximumIops
+	storageQoSPolicyIOPSCurrent *prometheus.Desc // sum of MSFT_StorageQoSFlow.StorageNodeIOPS per policy
+}
+
+// msftStorageQoSPolicy represents the MSFT_StorageQoSPolicy WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/stqosprov/msft-storageqospolicy
+type msftStorageQoSPolicy struct {
+	Name        string `mi:"Name"`
+	PolicyID    string `mi:"PolicyId"`
+	MaximumIops uint64 `mi:"MaximumIops"`
+}
+
+// msftStorageQoSFlow represents the MSFT_StorageQoSFlow WMI class.
+// There is one instance for each virtual disk opened on the storage cluster.
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/stqosprov/msft-storageqosflow
+type msftStorageQoSFlow struct {
+	PolicyID        string `mi:"PolicyId"`
+	StorageNodeIOPS uint64 `mi:"StorageNodeIOPS"`
+}
+
+func (c *Collector) buildStorageQoS() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	storageQoSPolicyMIQuery, err := mi.NewQuery("SELECT Name, PolicyId, MaximumIops FROM MSFT_StorageQoSPolicy")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	storageQoSFlowMIQuery, err := mi.NewQuery("SELECT PolicyId, StorageNodeIOPS FROM MSFT_StorageQoSFlow")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.storageQoSPolicyMIQuery = storageQoSPolicyMIQuery
+	c.storageQoSFlowMIQuery = storageQoSFlowMIQuery
+
+	c.storageQoSPolicyIOPSBudget = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "storage_qos_policy_iops_budget"),
+		"Represents the maximum normalized IOPS of the Storage QoS policy. 0 means unlimited.",
+		[]string{"policy"},
+		nil,
+	)
+	c.storageQoSPolicyIOPSCurrent = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "storage_qos_policy_iops_current"),
+		"Represents the sum of the normalized IOPS of all flows assigned to the Storage QoS policy.",
+		[]string{"policy"},
+		nil,
+	)
+
+	var dst []msftStorageQoSPolicy
+	if err := c.miSession.Query(&dst, mi.NamespaceRootStorage, c.storageQoSPolicyMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectStorageQoS(ch chan<- prometheus.Metric) error {
+	var policies []msftStorageQoSPolicy
+	if err := c.miSession.Query(&policies, mi.NamespaceRootStorage, c.storageQoSPolicyMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var flows []msftStorageQoSFlow
+	if err := c.miSession.Query(&flows, mi.NamespaceRootStorage, c.storageQoSFlowMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	currentIOPS := make(map[string]float64, len(policies))
+
+	for _, flow := range flows {
+		currentIOPS[flow.PolicyID] += float64(flow.StorageNodeIOPS)
+	}
+
+	for _, policy := range policies {
+		ch <- prometheus.MustNewConstMetric(
+			c.storageQoSPolicyIOPSBudget,
+			prometheus.GaugeValue,
+			float64(policy.MaximumIops),
+			policy.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.storageQoSPolicyIOPSCurrent,
+			prometheus.GaugeValue,
+			currentIOPS[policy.PolicyID],
+			policy.Name,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorVirtualIDEController Hyper-V Virtual IDE Controller (Emulated) metrics
+// The counter set has no error counters. Synthetic SCSI controllers have no counter set of their own,
+// the attached disks are covered by the Hyper-V Virtual Storage Device counters.
+type collectorVirtualIDEController struct {
+	perfDataCollectorVirtualIDEController *pdh.Collector
+	perfDataObjectVirtualIDEController    []perfDataCounterValuesVirtualIDEController
+
+	virtualIDEControllerReadBytes    *prometheus.Desc // \Hyper-V Virtual IDE Controller (Emulated)(*)\Read Bytes/sec
+	virtualIDEControllerWriteBytes   *prometheus.Desc // \Hyper-V Virtual IDE Controller (Emulated)(*)\Write Bytes/sec
+	virtualIDEControllerReadSectors  *prometheus.Desc // \Hyper-V Virtual IDE Controller (Emulated)(*)\Read Sectors/sec
+	virtualIDEControllerWriteSectors *prometheus.Desc // \Hyper-V Virtual IDE Controller (Emulated)(*)\Written Sectors/sec
+}
+
+type perfDataCounterValuesVirtualIDEController struct {
+	Name string
+
+	VirtualIDEControllerReadBytes    float64 `perfdata:"Read Bytes/sec"`
+	VirtualIDEControllerWriteBytes   float64 `perfdata:"Write Bytes/sec"`
+	VirtualIDEControllerReadSectors  float64 `perfdata:"Read Sectors/sec"`
+	VirtualIDEControllerWriteSectors float64 `perfdata:"Written Sectors/sec"`
+}
+
+func (c *Collector) buildVirtualIDEController() error {
+	var err error
+
+	c.perfDataCollectorVirtualIDEController, err = pdh.NewCollector[perfDataCounterValuesVirtualIDEController](c.logger, pdh.CounterTypeRaw, "Hyper-V Virtual IDE Controller (Emulated)", pdh.InstancesAll, c.pdhOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create Hyper-V Virtual IDE Controller (Emulated) collector: %w", err)
+	}
+
+	c.virtualIDEControllerReadBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "virtual_ide_controller_read_bytes_total"),
+		"Represents the total number of bytes read by the emulated IDE controller.",
+		[]string{"vm", "controller"},
+		nil,
+	)
+	c.virtualIDEControllerWriteBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "virtual_ide_controller_write_bytes_total"),
+		"Represents the total number of bytes written by the emulated IDE controller.",
+		[]string{"vm", "controller"},
+		nil,
+	)
+	c.virtualIDEControllerReadSectors = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "virtual_ide_controller_read_sectors_total"),
+		"Represents the total number of sectors read by the emulated IDE controller.",
+		[]string{"vm", "controller"},
+		nil,
+	)
+	c.virtualIDEControllerWriteSectors = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "virtual_ide_controller_write_sectors_total"),
+		"Represents the total number of sectors written by the emulated IDE controller.",
+		[]string{"vm", "controller"},
+		nil,
+	)
+
+	return nil
+}
+
+func (c *Collector) collectVirtualIDEController(ch chan<- prometheus.Metric) error {
+	err := c.perfDataCollectorVirtualIDEController.Collect(&c.perfDataObjectVirtualIDEController)
+	if err != nil {
+		return fmt.Errorf("failed to collect Hyper-V Virtual IDE Controller (Emulated) metrics: %w", err)
+	}
+
+	for _, data := range c.perfDataObjectVirtualIDEController {
+		// The name format is <VM Name>:<controller>
+		vmName, controller, ok := splitVMInstanceName(data.Name)
+		if !ok {
+			return fmt.Errorf("unexpected format of Name in Hyper-V Virtual IDE Controller (Emulated): %q, expected %q", data.Name, "<VM Name>:<controller>")
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.virtualIDEControllerReadBytes,
+			prometheus.CounterValue,
+			data.VirtualIDEControllerReadBytes,
+			vmName, controller,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.virtualIDEControllerWriteBytes,
+			prometheus.CounterValue,
+			data.VirtualIDEControllerWriteBytes,
+			vmName, controller,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.virtualIDEControllerReadSectors,
+			prometheus.CounterValue,
+			data.VirtualIDEControllerReadSectors,
+			vmName, controller,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.virtualIDEControllerWriteSectors,
+			prometheus.CounterValue,
+			data.VirtualIDEControllerWriteSectors,
+			vmName, controller,
+		)
+	}
+
+	return nil
+}
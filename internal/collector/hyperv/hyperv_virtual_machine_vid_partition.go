@@ -35,8 +35,10 @@ type collectorVirtualMachineVidPartition struct {
 	remotePhysicalPages    *prometheus.Desc // \Hyper-V VM Vid Partition(*)\Remote Physical Pages
 }
 
+// The instances of the Hyper-V VM Vid Partition counter set are named after the VMs.
 type perfDataCounterValuesVirtualMachineVidPartition struct {
-	Name string
+	_      struct{} `pdh:"name:VMName"`
+	VMName string
 
 	PhysicalPagesAllocated float64 `perfdata:"Physical Pages Allocated"`
 	PreferredNUMANodeIndex float64 `perfdata:"Preferred NUMA Node Index"`
@@ -46,7 +48,7 @@ type perfDataCounterValuesVirtualMachineVidPartition struct {
 func (c *Collector) buildVirtualMachineVidPartition() error {
 	var err error
 
-	c.perfDataCollectorVirtualMachineVidPartition, err = pdh.NewCollector[perfDataCounterValuesVirtualMachineVidPartition](c.logger, pdh.CounterTypeRaw, "Hyper-V VM Vid Partition", pdh.InstancesAll)
+	c.perfDataCollectorVirtualMachineVidPartition, err = pdh.NewCollector[perfDataCounterValuesVirtualMachineVidPartition](c.logger, pdh.CounterTypeRaw, "Hyper-V VM Vid Partition", pdh.InstancesAll, c.pdhOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create Hyper-V VM Vid Partition collector: %w", err)
 	}
@@ -84,21 +86,21 @@ func (c *Collector) collectVirtualMachineVidPartition(ch chan<- prometheus.Metri
 			c.physicalPagesAllocated,
 			prometheus.GaugeValue,
 			data.PhysicalPagesAllocated,
-			data.Name,
+			data.VMName,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.preferredNUMANodeIndex,
 			prometheus.GaugeValue,
 			data.PreferredNUMANodeIndex,
-			data.Name,
+			data.VMName,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.remotePhysicalPages,
 			prometheus.GaugeValue,
 			data.RemotePhysicalPages,
-			data.Name,
+			data.VMName,
 		)
 	}
 
@@ -52,7 +52,7 @@ type perfDataCounterValuesVirtualNetworkAdapter struct {
 func (c *Collector) buildVirtualNetworkAdapter() error {
 	var err error
 
-	c.perfDataCollectorVirtualNetworkAdapter, err = pdh.NewCollector[perfDataCounterValuesVirtualNetworkAdapter](c.logger, pdh.CounterTypeRaw, "Hyper-V Virtual Network Adapter", pdh.InstancesAll)
+	c.perfDataCollectorVirtualNetworkAdapter, err = pdh.NewCollector[perfDataCounterValuesVirtualNetworkAdapter](c.logger, pdh.CounterTypeRaw, "Hyper-V Virtual Network Adapter", pdh.InstancesAll, c.pdhOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create Hyper-V Virtual Network Adapter collector: %w", err)
 	}
@@ -103,6 +103,12 @@ func (c *Collector) collectVirtualNetworkAdapter(ch chan<- prometheus.Metric) er
 		return fmt.Errorf("failed to collect Hyper-V Virtual Network Adapter metrics: %w", err)
 	}
 
+	if !c.config.IncludeLegacyDevices {
+		c.perfDataObjectVirtualNetworkAdapter = excludeLegacyEmulatedDevices(c.perfDataObjectVirtualNetworkAdapter, func(data perfDataCounterValuesVirtualNetworkAdapter) string {
+			return data.Name
+		})
+	}
+
 	for _, data := range c.perfDataObjectVirtualNetworkAdapter {
 		ch <- prometheus.MustNewConstMetric(
 			c.virtualNetworkAdapterBytesReceived,
@@ -18,30 +18,68 @@
 package hyperv
 
 import (
+	"cmp"
 	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
-	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// virtualStorageDeviceHostResourcesRefreshInterval is the interval in which the backing files of the
+// virtual storage devices are refreshed. Devices added in between are labelled by their full instance name
+// until the next refresh.
+const virtualStorageDeviceHostResourcesRefreshInterval = 5 * time.Minute
+
 // Hyper-V Virtual Storage Device metrics
 type collectorVirtualStorageDevice struct {
 	perfDataCollectorVirtualStorageDevice *pdh.Collector
 	perfDataObjectVirtualStorageDevice    []perfDataCounterValuesVirtualStorageDevice
 
+	// virtualStorageDeviceTargetQueueDepths are the target queue depths used for the queue saturation.
+	virtualStorageDeviceTargetQueueDepths targetQueueDepths
+
+	virtualStorageDeviceHostResourceMIQuery mi.Query
+
+	virtualStorageDeviceHostResourcesMu          sync.Mutex
+	virtualStorageDeviceHostResourcesCache       []string
+	virtualStorageDeviceHostResourcesLastRefresh time.Time
+
+	// virtualStorageDeviceCollectErrors counts the failed collections of the performance counters.
+	virtualStorageDeviceCollectErrors atomic.Uint64
+
 	virtualStorageDeviceErrorCount               *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Error Count
 	virtualStorageDeviceQueueLength              *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Queue Length
+	virtualStorageDeviceQueueSaturation          *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Queue Length divided by the target queue depth
 	virtualStorageDeviceReadBytes                *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Read Bytes/sec
 	virtualStorageDeviceReadOperations           *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Read Operations/Sec
 	virtualStorageDeviceWriteBytes               *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Write Bytes/sec
 	virtualStorageDeviceWriteOperations          *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Write Operations/Sec
 	virtualStorageDeviceLatency                  *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Latency
 	virtualStorageDeviceThroughput               *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Throughput
+	virtualStorageDeviceThroughputBytes          *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Throughput multiplied by 8KB
 	virtualStorageDeviceNormalizedThroughput     *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Normalized Throughput
 	virtualStorageDeviceLowerQueueLength         *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Lower Queue Length
 	virtualStorageDeviceLowerLatency             *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Lower Latency
+	virtualStorageDeviceLatencyOverhead          *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\Latency minus Lower Latency
 	virtualStorageDeviceIOQuotaReplenishmentRate *prometheus.Desc // \Hyper-V Virtual Storage Device(*)\IO Quota Replenishment Rate
+	virtualStorageDeviceCollectErrorsTotal       *prometheus.Desc
+}
+
+// msvmStorageAllocationSettingDataHostResource represents the backing files of a Msvm_StorageAllocationSettingData WMI instance.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-storageallocationsettingdata
+type msvmStorageAllocationSettingDataHostResource struct {
+	InstanceID   string   `mi:"InstanceID"`
+	HostResource []string `mi:"HostResource"`
 }
 
 type perfDataCounterValuesVirtualStorageDevice struct {
@@ -64,100 +102,148 @@ type perfDataCounterValuesVirtualStorageDevice struct {
 func (c *Collector) buildVirtualStorageDevice() error {
 	var err error
 
-	c.perfDataCollectorVirtualStorageDevice, err = pdh.NewCollector[perfDataCounterValuesVirtualStorageDevice](c.logger, pdh.CounterTypeRaw, "Hyper-V Virtual Storage Device", pdh.InstancesAll)
+	c.perfDataCollectorVirtualStorageDevice, err = pdh.NewCollector[perfDataCounterValuesVirtualStorageDevice](c.logger, pdh.CounterTypeRaw, "Hyper-V Virtual Storage Device", pdh.InstancesAll, c.pdhOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create Hyper-V Virtual Storage Device collector: %w", err)
 	}
 
-	c.virtualStorageDeviceErrorCount = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_error_count_total"),
+	c.virtualStorageDeviceTargetQueueDepths, err = parseTargetQueueDepths(c.config.TargetQueueDepth, c.config.TargetQueueDepthOverrides)
+	if err != nil {
+		return err
+	}
+
+	c.virtualStorageDeviceErrorCount = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_error_count_total",
 		"Represents the total number of errors that have occurred on this virtual device.",
 		[]string{"device"},
-		nil,
 	)
-	c.virtualStorageDeviceQueueLength = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_queue_length"),
+	c.virtualStorageDeviceQueueLength = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_queue_length",
 		"Represents the average queue length on this virtual device.",
 		[]string{"device"},
-		nil,
 	)
-	c.virtualStorageDeviceReadBytes = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_bytes_read"),
+	c.virtualStorageDeviceQueueSaturation = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_queue_saturation",
+		"Represents the average queue length on this virtual device divided by the configured target queue depth.",
+		[]string{"device"},
+	)
+	c.virtualStorageDeviceReadBytes = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_bytes_read",
 		"Represents the total number of bytes that have been read on this virtual device.",
 		[]string{"device"},
-		nil,
 	)
-	c.virtualStorageDeviceReadOperations = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_operations_read_total"),
+	c.virtualStorageDeviceReadOperations = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_operations_read_total",
 		"Represents the total number of read operations that have occurred on this virtual device.",
 		[]string{"device"},
-		nil,
 	)
-	c.virtualStorageDeviceWriteBytes = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_bytes_written"),
+	c.virtualStorageDeviceWriteBytes = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_bytes_written",
 		"Represents the total number of bytes that have been written on this virtual device.",
 		[]string{"device"},
-		nil,
 	)
-	c.virtualStorageDeviceWriteOperations = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_operations_written_total"),
+	c.virtualStorageDeviceWriteOperations = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_operations_written_total",
 		"Represents the total number of write operations that have occurred on this virtual device.",
 		[]string{"device"},
-		nil,
 	)
-	c.virtualStorageDeviceLatency = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_latency_seconds"),
+	c.virtualStorageDeviceLatency = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_latency_seconds",
 		"Represents the average IO transfer latency for this virtual device.",
 		[]string{"device"},
-		nil,
-	)
-	c.virtualStorageDeviceThroughput = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_throughput_total"),
-		"Represents the total number of 8KB IO transfers completed by this virtual device.",
-		[]string{"device"},
-		nil,
 	)
-	c.virtualStorageDeviceNormalizedThroughput = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_normalized_throughput"),
-		"Represents the average number of IO transfers completed by this virtual device.",
-		[]string{"device"},
-		nil,
-	)
-	c.virtualStorageDeviceLowerQueueLength = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_lower_queue_length"),
+	c.buildVirtualStorageDeviceThroughput()
+
+	c.virtualStorageDeviceLowerQueueLength = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_lower_queue_length",
 		"Represents the average queue length on the underlying storage subsystem for this device.",
 		[]string{"device"},
-		nil,
 	)
-	c.virtualStorageDeviceLowerLatency = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_lower_latency_seconds"),
+	c.virtualStorageDeviceLowerLatency = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_lower_latency_seconds",
 		"Represents the average IO transfer latency on the underlying storage subsystem for this virtual device.",
 		[]string{"device"},
-		nil,
 	)
-	c.virtualStorageDeviceIOQuotaReplenishmentRate = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "io_quota_replenishment_rate"),
+	c.virtualStorageDeviceLatencyOverhead = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_latency_overhead_seconds",
+		"Represents the average IO transfer latency added by the Hyper-V storage stack for this virtual device, i.e. the latency minus the lower latency. Negative differences are reported as 0.",
+		[]string{"device"},
+	)
+	c.virtualStorageDeviceIOQuotaReplenishmentRate = bdf.NewDesc(
+		Name,
+		"io_quota_replenishment_rate",
 		"Represents the IO quota replenishment rate for this virtual device.",
 		[]string{"device"},
+	)
+	c.virtualStorageDeviceCollectErrorsTotal = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_collect_errors_total",
+		"Represents the number of failed collections of the Hyper-V Virtual Storage Device performance counters.",
 		nil,
 	)
+	c.virtualStorageDeviceHostResourceMIQuery, err = mi.Select("InstanceID", "HostResource").From("Msvm_StorageAllocationSettingData").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
 
 	return nil
 }
 
 func (c *Collector) collectVirtualStorageDevice(ch chan<- prometheus.Metric) error {
 	err := c.perfDataCollectorVirtualStorageDevice.Collect(&c.perfDataObjectVirtualStorageDevice)
+	if err != nil {
+		c.virtualStorageDeviceCollectErrors.Add(1)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.virtualStorageDeviceCollectErrorsTotal,
+		prometheus.CounterValue,
+		float64(c.virtualStorageDeviceCollectErrors.Load()),
+	)
+
 	if err != nil {
 		return fmt.Errorf("failed to collect Hyper-V Virtual Storage Device metrics: %w", err)
 	}
 
+	if !c.config.IncludeLegacyDevices {
+		c.perfDataObjectVirtualStorageDevice = excludeLegacyEmulatedDevices(c.perfDataObjectVirtualStorageDevice, func(data perfDataCounterValuesVirtualStorageDevice) string {
+			return data.Name
+		})
+	}
+
+	devices := make([]string, 0, len(c.perfDataObjectVirtualStorageDevice))
 	for _, data := range c.perfDataObjectVirtualStorageDevice {
-		ch <- prometheus.MustNewConstMetric(
-			c.virtualStorageDeviceErrorCount,
-			prometheus.CounterValue,
-			data.VirtualStorageDeviceErrorCount,
-			data.Name,
-		)
+		devices = append(devices, data.Name)
+	}
+
+	hostResourcePaths := virtualStorageDeviceHostResourcePaths(c.virtualStorageDeviceHostResources())
+	deviceLabels := virtualStorageDeviceLabels(c.config.VirtualStorageDeviceLabelStyle, devices, hostResourcePaths)
+
+	for _, data := range c.perfDataObjectVirtualStorageDevice {
+		invalid := c.sanitizeVirtualStorageDevice(&data)
+		targetQueueDepth := c.virtualStorageDeviceTargetQueueDepths.forDevice(data.Name, hostResourcePaths[virtualStorageDeviceInstanceKey(data.Name)])
+
+		data.Name = deviceLabels[data.Name]
+
+		if !slices.Contains(invalid, "Error Count") {
+			ch <- prometheus.MustNewConstMetric(
+				c.virtualStorageDeviceErrorCount,
+				prometheus.CounterValue,
+				data.VirtualStorageDeviceErrorCount,
+				data.Name,
+			)
+		}
 
 		ch <- prometheus.MustNewConstMetric(
 			c.virtualStorageDeviceQueueLength,
@@ -166,33 +252,50 @@ func (c *Collector) collectVirtualStorageDevice(ch chan<- prometheus.Metric) err
 			data.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.virtualStorageDeviceReadBytes,
-			prometheus.CounterValue,
-			data.VirtualStorageDeviceReadBytes,
-			data.Name,
-		)
+		if targetQueueDepth > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				c.virtualStorageDeviceQueueSaturation,
+				prometheus.GaugeValue,
+				data.VirtualStorageDeviceQueueLength/targetQueueDepth,
+				data.Name,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.virtualStorageDeviceReadOperations,
-			prometheus.CounterValue,
-			data.VirtualStorageDeviceReadOperations,
-			data.Name,
-		)
+		if !slices.Contains(invalid, "Read Bytes/sec") {
+			ch <- prometheus.MustNewConstMetric(
+				c.virtualStorageDeviceReadBytes,
+				prometheus.CounterValue,
+				data.VirtualStorageDeviceReadBytes,
+				data.Name,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.virtualStorageDeviceWriteBytes,
-			prometheus.CounterValue,
-			data.VirtualStorageDeviceWriteBytes,
-			data.Name,
-		)
+		if !slices.Contains(invalid, "Read Count") {
+			ch <- prometheus.MustNewConstMetric(
+				c.virtualStorageDeviceReadOperations,
+				prometheus.CounterValue,
+				data.VirtualStorageDeviceReadOperations,
+				data.Name,
+			)
+		}
 
-		ch <- prometheus.MustNewConstMetric(
-			c.virtualStorageDeviceWriteOperations,
-			prometheus.CounterValue,
-			data.VirtualStorageDeviceWriteOperations,
-			data.Name,
-		)
+		if !slices.Contains(invalid, "Write Bytes/sec") {
+			ch <- prometheus.MustNewConstMetric(
+				c.virtualStorageDeviceWriteBytes,
+				prometheus.CounterValue,
+				data.VirtualStorageDeviceWriteBytes,
+				data.Name,
+			)
+		}
+
+		if !slices.Contains(invalid, "Write Count") {
+			ch <- prometheus.MustNewConstMetric(
+				c.virtualStorageDeviceWriteOperations,
+				prometheus.CounterValue,
+				data.VirtualStorageDeviceWriteOperations,
+				data.Name,
+			)
+		}
 
 		ch <- prometheus.MustNewConstMetric(
 			c.virtualStorageDeviceLatency,
@@ -201,19 +304,7 @@ func (c *Collector) collectVirtualStorageDevice(ch chan<- prometheus.Metric) err
 			data.Name,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
-			c.virtualStorageDeviceThroughput,
-			prometheus.CounterValue,
-			data.VirtualStorageDeviceThroughput,
-			data.Name,
-		)
-
-		ch <- prometheus.MustNewConstMetric(
-			c.virtualStorageDeviceNormalizedThroughput,
-			prometheus.GaugeValue,
-			data.VirtualStorageDeviceNormalizedThroughput,
-			data.Name,
-		)
+		c.collectVirtualStorageDeviceThroughput(ch, data, invalid)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.virtualStorageDeviceLowerQueueLength,
@@ -229,6 +320,16 @@ func (c *Collector) collectVirtualStorageDevice(ch chan<- prometheus.Metric) err
 			data.Name,
 		)
 
+		// The overhead is only meaningful if both latencies are valid.
+		if !slices.Contains(invalid, "Latency") && !slices.Contains(invalid, "Lower Latency") {
+			ch <- prometheus.MustNewConstMetric(
+				c.virtualStorageDeviceLatencyOverhead,
+				prometheus.GaugeValue,
+				virtualStorageDeviceLatencyOverhead(data.VirtualStorageDeviceLatency, data.VirtualStorageDeviceLowerLatency),
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
 			c.virtualStorageDeviceIOQuotaReplenishmentRate,
 			prometheus.GaugeValue,
@@ -239,3 +340,326 @@ func (c *Collector) collectVirtualStorageDevice(ch chan<- prometheus.Metric) err
 
 	return nil
 }
+
+// virtualStorageDeviceHostResources returns the backing files of all VMs and snapshots. They are only queried
+// for the filename label style and the target queue depth overrides, which resolve the paths of the devices from them,
+// and cached for virtualStorageDeviceHostResourcesRefreshInterval. If the backing files can't be queried, e.g. without
+// a WMI session, the last known backing files are returned. Devices without a backing file fall back to the instance name.
+func (c *Collector) virtualStorageDeviceHostResources() []string {
+	if c.miSession == nil {
+		return nil
+	}
+
+	if c.config.VirtualStorageDeviceLabelStyle != virtualStorageDeviceLabelStyleFilename && len(c.virtualStorageDeviceTargetQueueDepths.overrides) == 0 {
+		return nil
+	}
+
+	c.virtualStorageDeviceHostResourcesMu.Lock()
+	defer c.virtualStorageDeviceHostResourcesMu.Unlock()
+
+	if time.Since(c.virtualStorageDeviceHostResourcesLastRefresh) < virtualStorageDeviceHostResourcesRefreshInterval {
+		return c.virtualStorageDeviceHostResourcesCache
+	}
+
+	var settings []msvmStorageAllocationSettingDataHostResource
+	if err := c.miSession.Query(&settings, mi.NamespaceRootVirtualizationV2, c.virtualStorageDeviceHostResourceMIQuery); err != nil {
+		c.logger.Warn("failed to refresh the backing files of the Hyper-V virtual storage devices, using last known backing files",
+			slog.Any("err", err),
+		)
+
+		return c.virtualStorageDeviceHostResourcesCache
+	}
+
+	hostResources := make([]string, 0, len(settings))
+	for _, setting := range settings {
+		hostResources = append(hostResources, setting.HostResource...)
+	}
+
+	c.virtualStorageDeviceHostResourcesCache = hostResources
+	c.virtualStorageDeviceHostResourcesLastRefresh = time.Now()
+
+	return hostResources
+}
+
+// virtualStorageDeviceThroughputTransferSize is the size of the IO transfers counted by the Throughput counter.
+const virtualStorageDeviceThroughputTransferSize = 8 * 1024
+
+// buildVirtualStorageDeviceThroughput builds the throughput metrics. The Throughput counter counts IO transfers
+// normalized to 8KB, the Normalized Throughput counter is the rate of IO transfers regardless of their size.
+func (c *Collector) buildVirtualStorageDeviceThroughput() {
+	c.virtualStorageDeviceThroughput = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_throughput_total",
+		"Represents the total number of IO transfers completed by this virtual device, normalized to 8KB transfers.",
+		[]string{"device"},
+	)
+	c.virtualStorageDeviceThroughputBytes = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_throughput_bytes_total",
+		"Represents the total number of bytes transferred by this virtual device, derived from the IO transfers normalized to 8KB transfers.",
+		[]string{"device"},
+	)
+	c.virtualStorageDeviceNormalizedThroughput = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_normalized_throughput",
+		"Represents the number of IO transfers per second completed by this virtual device, regardless of their size.",
+		[]string{"device"},
+	)
+}
+
+// collectVirtualStorageDeviceThroughput emits the throughput metrics. The throughput counters are skipped
+// if the Throughput counter is in invalid, see sanitizeVirtualStorageDevice.
+func (c *Collector) collectVirtualStorageDeviceThroughput(ch chan<- prometheus.Metric, data perfDataCounterValuesVirtualStorageDevice, invalid []string) {
+	if !slices.Contains(invalid, "Throughput") {
+		ch <- prometheus.MustNewConstMetric(
+			c.virtualStorageDeviceThroughput,
+			prometheus.CounterValue,
+			data.VirtualStorageDeviceThroughput,
+			data.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.virtualStorageDeviceThroughputBytes,
+			prometheus.CounterValue,
+			data.VirtualStorageDeviceThroughput*virtualStorageDeviceThroughputTransferSize,
+			data.Name,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.virtualStorageDeviceNormalizedThroughput,
+		prometheus.GaugeValue,
+		data.VirtualStorageDeviceNormalizedThroughput,
+		data.Name,
+	)
+}
+
+// VirtualStorageDeviceInstances returns the raw instance names of the Hyper-V Virtual Storage Device performance counter set.
+// It runs a dedicated performance counter query once and closes it afterward. No metrics are emitted.
+func VirtualStorageDeviceInstances(logger *slog.Logger) ([]string, error) {
+	collector, err := pdh.NewCollector[perfDataCounterValuesVirtualStorageDevice](logger, pdh.CounterTypeRaw, "Hyper-V Virtual Storage Device", pdh.InstancesAll, pdh.WithCollectorName(Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Hyper-V Virtual Storage Device collector: %w", err)
+	}
+
+	defer collector.Close()
+
+	var data []perfDataCounterValuesVirtualStorageDevice
+	if err := collector.Collect(&data); err != nil {
+		return nil, fmt.Errorf("failed to collect Hyper-V Virtual Storage Device metrics: %w", err)
+	}
+
+	instances := make([]string, 0, len(data))
+	for _, instance := range data {
+		instances = append(instances, instance.Name)
+	}
+
+	return instances, nil
+}
+
+// virtualStorageDeviceHostResourcePaths maps the host resource paths by the instance key they encode to,
+// see virtualStorageDeviceInstanceKey.
+func virtualStorageDeviceHostResourcePaths(hostResources []string) map[string]string {
+	paths := make(map[string]string, len(hostResources))
+	for _, path := range hostResources {
+		paths[virtualStorageDeviceInstanceKey(path)] = path
+	}
+
+	return paths
+}
+
+// virtualStorageDeviceLabels maps the instance names of the virtual storage devices to the device label values.
+// The instance name is the path of the virtual disk, with path separators replaced by dashes,
+// e.g. "D:-VMs-vm01-disk.vhdx". Since filenames may contain dashes as well, the filename can't be parsed from
+// the instance name. With the filename label style, the filename is taken from the host resource path that
+// encodes to the instance name instead, see virtualStorageDeviceHostResourcePaths. Devices without a matching
+// host resource use the full instance name. If multiple devices share the same filename, those devices use
+// the full instance name as well. The label of a device therefore changes when a device with the same filename
+// appears or disappears.
+func virtualStorageDeviceLabels(style string, devices []string, hostResourcePaths map[string]string) map[string]string {
+	labels := make(map[string]string, len(devices))
+
+	if style != virtualStorageDeviceLabelStyleFilename {
+		for _, device := range devices {
+			labels[device] = device
+		}
+
+		return labels
+	}
+
+	resolved := make(map[string]string, len(devices))
+
+	filenames := make(map[string]int, len(devices))
+
+	for _, device := range devices {
+		if path, ok := hostResourcePaths[virtualStorageDeviceInstanceKey(device)]; ok {
+			filename := path[strings.LastIndexAny(path, `\/`)+1:]
+			resolved[device] = filename
+			filenames[strings.ToLower(filename)]++
+		}
+	}
+
+	for _, device := range devices {
+		filename, ok := resolved[device]
+
+		if !ok || filenames[strings.ToLower(filename)] > 1 {
+			labels[device] = device
+		} else {
+			labels[device] = filename
+		}
+	}
+
+	return labels
+}
+
+// virtualStorageDeviceInstanceKey returns a case-insensitive key for a path or a performance counter instance name,
+// with all path separators replaced by dashes like in the instance names.
+func virtualStorageDeviceInstanceKey(path string) string {
+	return strings.ToLower(strings.NewReplacer(`\`, "-", "/", "-").Replace(path))
+}
+
+// virtualStorageDeviceLatencyOverhead returns the latency added on top of the underlying storage subsystem.
+// Since both latencies are averages, the difference can be slightly negative and is clamped to 0.
+func virtualStorageDeviceLatencyOverhead(latency, lowerLatency float64) float64 {
+	return max(latency-lowerLatency, 0)
+}
+
+// targetQueueDepths are the target queue depths of the virtual storage devices,
+// configured via --collector.hyperv.target-queue-depth and --collector.hyperv.target-queue-depth-overrides.
+type targetQueueDepths struct {
+	global    float64
+	overrides []targetQueueDepthOverride // sorted by descending prefix length
+}
+
+type targetQueueDepthOverride struct {
+	path   string // lower case path prefix with backslash separators, e.g. `d:\vms\tier1`
+	prefix string // lower case path prefix in the instance name format, e.g. "d:-vms-tier1"
+	depth  float64
+}
+
+// parseTargetQueueDepths parses the global target queue depth and the overrides in the format <path>=<depth>.
+// Since the instance names of the virtual storage devices encode path separators as dashes,
+// the paths of the overrides are converted the same way for devices without a known backing file.
+func parseTargetQueueDepths(global float64, overrides []string) (targetQueueDepths, error) {
+	if global < 0 || math.IsNaN(global) || math.IsInf(global, 0) {
+		return targetQueueDepths{}, fmt.Errorf("invalid target queue depth %v: expected a number greater than or equal to 0", global)
+	}
+
+	depths := targetQueueDepths{
+		global:    global,
+		overrides: make([]targetQueueDepthOverride, 0, len(overrides)),
+	}
+
+	for _, entry := range overrides {
+		// Paths may contain "=", the depth can't.
+		idx := strings.LastIndex(entry, "=")
+		if idx < 0 {
+			return targetQueueDepths{}, fmt.Errorf("invalid target queue depth override %q: expected <path>=<depth>", entry)
+		}
+
+		path := strings.ToLower(strings.TrimRight(strings.ReplaceAll(strings.TrimSpace(entry[:idx]), "/", `\`), `\`))
+
+		prefix := strings.NewReplacer(`\`, "-", "/", "-").Replace(strings.TrimSpace(entry[:idx]))
+		prefix = strings.ToLower(strings.Trim(prefix, "-"))
+
+		if prefix == "" {
+			return targetQueueDepths{}, fmt.Errorf("invalid target queue depth override %q: empty path", entry)
+		}
+
+		depth, err := strconv.ParseFloat(strings.TrimSpace(entry[idx+1:]), 64)
+		if err != nil || depth <= 0 || math.IsInf(depth, 0) {
+			return targetQueueDepths{}, fmt.Errorf("invalid target queue depth override %q: expected a depth greater than 0", entry)
+		}
+
+		depths.overrides = append(depths.overrides, targetQueueDepthOverride{path: path, prefix: prefix, depth: depth})
+	}
+
+	slices.SortStableFunc(depths.overrides, func(a, b targetQueueDepthOverride) int {
+		return cmp.Compare(len(b.prefix), len(a.prefix))
+	})
+
+	return depths, nil
+}
+
+// forDevice returns the target queue depth for the device with the given instance name and backing file path.
+// The override with the longest path prefix wins. If the path is known, a prefix matches only whole path elements,
+// e.g. "D:\VMs" doesn't match "D:\VMs-old\disk.vhdx". Otherwise, the prefix is matched against the instance name,
+// which encodes path separators as dashes. Then "D:\VMs" still doesn't match "D:-VMs2-disk.vhdx", but can't be
+// told apart from path elements which contain dashes, e.g. "D:-VMs-old-disk.vhdx".
+func (t targetQueueDepths) forDevice(instance, path string) float64 {
+	if path != "" {
+		path = strings.ToLower(strings.ReplaceAll(path, "/", `\`))
+
+		for _, override := range t.overrides {
+			if path == override.path || strings.HasPrefix(path, override.path+`\`) {
+				return override.depth
+			}
+		}
+
+		return t.global
+	}
+
+	instance = strings.ToLower(instance)
+
+	for _, override := range t.overrides {
+		if instance == override.prefix || strings.HasPrefix(instance, override.prefix+"-") {
+			return override.depth
+		}
+	}
+
+	return t.global
+}
+
+// sanitizeVirtualStorageDevice checks the values for values which can't be valid for the counter.
+// Raw PDH counters occasionally return garbage right after an instance appears, e.g. when a VM starts.
+// None of the Hyper-V Virtual Storage Device counters (bytes, counts, latencies, queue lengths) can be negative or infinite.
+// Invalid values of gauges are clamped to 0. Invalid values of cumulative counters are left unchanged, since a 0
+// would look like a counter reset. The series of those counters have to be skipped for this scrape instead.
+// The names of all counters with invalid values are returned in sorted order.
+func (c *Collector) sanitizeVirtualStorageDevice(data *perfDataCounterValuesVirtualStorageDevice) []string {
+	var invalid []string
+
+	for counter, value := range map[string]struct {
+		value      *float64
+		cumulative bool
+	}{
+		"Error Count":                 {&data.VirtualStorageDeviceErrorCount, true},
+		"Queue Length":                {&data.VirtualStorageDeviceQueueLength, false},
+		"Read Bytes/sec":              {&data.VirtualStorageDeviceReadBytes, true},
+		"Read Count":                  {&data.VirtualStorageDeviceReadOperations, true},
+		"Write Bytes/sec":             {&data.VirtualStorageDeviceWriteBytes, true},
+		"Write Count":                 {&data.VirtualStorageDeviceWriteOperations, true},
+		"Latency":                     {&data.VirtualStorageDeviceLatency, false},
+		"Throughput":                  {&data.VirtualStorageDeviceThroughput, true},
+		"Normalized Throughput":       {&data.VirtualStorageDeviceNormalizedThroughput, false},
+		"Lower Queue Length":          {&data.VirtualStorageDeviceLowerQueueLength, false},
+		"Lower Latency":               {&data.VirtualStorageDeviceLowerLatency, false},
+		"IO Quota Replenishment Rate": {&data.VirtualStorageDeviceIOQuotaReplenishmentRate, false},
+	} {
+		if *value.value >= 0 && !math.IsInf(*value.value, 1) {
+			continue
+		}
+
+		if value.cumulative {
+			c.logger.Debug("dropping invalid Hyper-V Virtual Storage Device counter value",
+				slog.String("device", data.Name),
+				slog.String("counter", counter),
+				slog.Float64("value", *value.value),
+			)
+		} else {
+			c.logger.Debug("clamping invalid Hyper-V Virtual Storage Device gauge value to 0",
+				slog.String("device", data.Name),
+				slog.String("counter", counter),
+				slog.Float64("value", *value.value),
+			)
+
+			*value.value = 0
+		}
+
+		invalid = append(invalid, counter)
+	}
+
+	slices.Sort(invalid)
+
+	return invalid
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// resourceSubTypeVirtualDVDDisk is the ResourceSubType of the Msvm_StorageAllocationSettingData of ISO images.
+const resourceSubTypeVirtualDVDDisk = "Microsoft:Hyper-V:Virtual CD/DVD Disk"
+
+// collectorVirtualStorageDeviceISO Hyper-V ISO images mounted in virtual DVD drives
+type collectorVirtualStorageDeviceISO struct {
+	virtualStorageDeviceISOComputerSystemMIQuery mi.Query
+	virtualStorageDeviceISOSettingsMIQuery       mi.Query
+
+	virtualStorageDeviceISOMountedInfo *prometheus.Desc // Msvm_StorageAllocationSettingData.HostResource of virtual CD/DVD disks
+}
+
+func (c *Collector) buildVirtualStorageDeviceISO() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	c.virtualStorageDeviceISOComputerSystemMIQuery, err = mi.Select("Name", "ElementName").
+		From("Msvm_ComputerSystem").
+		WhereEqual("Caption", "Virtual Machine").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.virtualStorageDeviceISOSettingsMIQuery, err = mi.Select("InstanceID", "HostResource").
+		From("Msvm_StorageAllocationSettingData").
+		WhereEqual("ResourceSubType", resourceSubTypeVirtualDVDDisk).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.virtualStorageDeviceISOMountedInfo = bdf.NewDesc(
+		Name,
+		"virtual_storage_device_iso_mounted_info",
+		"Represents an ISO image mounted in a virtual DVD drive of the virtual machine. Always 1.",
+		[]string{"vm", "path"},
+	)
+
+	var dst []msvmStorageAllocationSettingDataHostResource
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.virtualStorageDeviceISOSettingsMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+// collectVirtualStorageDeviceISO emits the ISO images mounted in the virtual DVD drives. Mounted ISO images
+// are reported regardless of the state of the VM, since they don't have performance counter instances while
+// the VM is off. Settings of snapshots don't resolve to a VM and are skipped.
+func (c *Collector) collectVirtualStorageDeviceISO(ch chan<- prometheus.Metric) error {
+	var settings []msvmStorageAllocationSettingDataHostResource
+	if err := c.miSession.Query(&settings, mi.NamespaceRootVirtualizationV2, c.virtualStorageDeviceISOSettingsMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var vms []msvmComputerSystemID
+	if err := c.miSession.Query(&vms, mi.NamespaceRootVirtualizationV2, c.virtualStorageDeviceISOComputerSystemMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	vmNames := vmNamesByID(vms)
+
+	for _, setting := range settings {
+		vmName, ok := vmNameFromInstanceID(vmNames, setting.InstanceID)
+		if !ok {
+			continue
+		}
+
+		for _, path := range setting.HostResource {
+			// Physical drives passed through to the VM are host resources as well.
+			if !strings.EqualFold(filepath.Ext(path), ".iso") {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.virtualStorageDeviceISOMountedInfo,
+				prometheus.GaugeValue,
+				1,
+				vmName,
+				path,
+			)
+		}
+	}
+
+	return nil
+}
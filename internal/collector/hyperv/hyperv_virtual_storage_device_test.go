@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"log/slog"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeVirtualStorageDevice(t *testing.T) {
+	t.Parallel()
+
+	c := &Collector{logger: slog.New(slog.DiscardHandler)}
+
+	data := perfDataCounterValuesVirtualStorageDevice{
+		Name:                                     `D:-VMs-vm01-disk.vhdx`,
+		VirtualStorageDeviceErrorCount:           -1,
+		VirtualStorageDeviceQueueLength:          3,
+		VirtualStorageDeviceReadBytes:            -9.223372036854776e+18,
+		VirtualStorageDeviceReadOperations:       42,
+		VirtualStorageDeviceWriteBytes:           math.NaN(),
+		VirtualStorageDeviceWriteOperations:      math.Inf(1),
+		VirtualStorageDeviceLatency:              0.005,
+		VirtualStorageDeviceLowerLatency:         math.Inf(-1),
+		VirtualStorageDeviceLowerQueueLength:     -2,
+		VirtualStorageDeviceNormalizedThroughput: 0,
+	}
+
+	invalid := c.sanitizeVirtualStorageDevice(&data)
+
+	require.Equal(t, []string{"Error Count", "Lower Latency", "Lower Queue Length", "Read Bytes/sec", "Write Bytes/sec", "Write Count"}, invalid)
+
+	// Only the gauges are clamped, invalid cumulative counters are kept for the caller to skip.
+	require.Equal(t, float64(0), data.VirtualStorageDeviceLowerLatency)
+	require.Equal(t, float64(0), data.VirtualStorageDeviceLowerQueueLength)
+	require.Equal(t, float64(-1), data.VirtualStorageDeviceErrorCount)
+	require.Equal(t, -9.223372036854776e+18, data.VirtualStorageDeviceReadBytes)
+	require.True(t, math.IsNaN(data.VirtualStorageDeviceWriteBytes))
+	require.Equal(t, math.Inf(1), data.VirtualStorageDeviceWriteOperations)
+	require.Equal(t, float64(3), data.VirtualStorageDeviceQueueLength)
+	require.Equal(t, float64(42), data.VirtualStorageDeviceReadOperations)
+	require.Equal(t, 0.005, data.VirtualStorageDeviceLatency)
+}
+
+func TestVirtualStorageDeviceLabels(t *testing.T) {
+	t.Parallel()
+
+	devices := []string{
+		`D:-VMs-vm01-disk.vhdx`,
+		`D:-VMs-vm02-disk.vhdx`,
+		`D:-VMs-vm02-data.vhdx`,
+		`\\fs01-share-vm03-os.vhdx`,
+		`C:-Disks-web-01.vhdx`,
+		`D:-VMs-vm04-unknown.vhdx`,
+	}
+
+	hostResources := []string{
+		`D:\VMs\vm01\disk.vhdx`,
+		`D:\VMs\vm02\disk.vhdx`,
+		`d:\vms\vm02\data.vhdx`,
+		`\\fs01\share\vm03\os.vhdx`,
+		`C:\Disks\web-01.vhdx`,
+		`E:\ISOs\install.iso`,
+	}
+
+	for _, tc := range []struct {
+		style    string
+		expected map[string]string
+	}{
+		{
+			style: virtualStorageDeviceLabelStyleFull,
+			expected: map[string]string{
+				`D:-VMs-vm01-disk.vhdx`:     `D:-VMs-vm01-disk.vhdx`,
+				`D:-VMs-vm02-disk.vhdx`:     `D:-VMs-vm02-disk.vhdx`,
+				`D:-VMs-vm02-data.vhdx`:     `D:-VMs-vm02-data.vhdx`,
+				`\\fs01-share-vm03-os.vhdx`: `\\fs01-share-vm03-os.vhdx`,
+				`C:-Disks-web-01.vhdx`:      `C:-Disks-web-01.vhdx`,
+				`D:-VMs-vm04-unknown.vhdx`:  `D:-VMs-vm04-unknown.vhdx`,
+			},
+		},
+		{
+			style: virtualStorageDeviceLabelStyleFilename,
+			expected: map[string]string{
+				`D:-VMs-vm01-disk.vhdx`:     `D:-VMs-vm01-disk.vhdx`,
+				`D:-VMs-vm02-disk.vhdx`:     `D:-VMs-vm02-disk.vhdx`,
+				`D:-VMs-vm02-data.vhdx`:     `data.vhdx`,
+				`\\fs01-share-vm03-os.vhdx`: `os.vhdx`,
+				`C:-Disks-web-01.vhdx`:      `web-01.vhdx`,
+				`D:-VMs-vm04-unknown.vhdx`:  `D:-VMs-vm04-unknown.vhdx`,
+			},
+		},
+	} {
+		t.Run(tc.style, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, virtualStorageDeviceLabels(tc.style, devices, virtualStorageDeviceHostResourcePaths(hostResources)))
+
+			// The result must not depend on the order of the devices.
+			reversed := slices.Clone(devices)
+			slices.Reverse(reversed)
+
+			require.Equal(t, tc.expected, virtualStorageDeviceLabels(tc.style, reversed, virtualStorageDeviceHostResourcePaths(hostResources)))
+		})
+	}
+}
+
+func TestVirtualStorageDeviceThroughputTypes(t *testing.T) {
+	t.Parallel()
+
+	c := &Collector{}
+	c.buildVirtualStorageDeviceThroughput()
+
+	ch := make(chan prometheus.Metric, 3)
+
+	c.collectVirtualStorageDeviceThroughput(ch, perfDataCounterValuesVirtualStorageDevice{
+		Name:                                     "disk.vhdx",
+		VirtualStorageDeviceThroughput:           10,
+		VirtualStorageDeviceNormalizedThroughput: 25,
+	}, nil)
+
+	close(ch)
+
+	type result struct {
+		counter bool
+		value   float64
+	}
+
+	results := make(map[string]result, 3)
+
+	for metric := range ch {
+		var m dto.Metric
+		require.NoError(t, metric.Write(&m))
+
+		if m.GetCounter() != nil {
+			results[metric.Desc().String()] = result{counter: true, value: m.GetCounter().GetValue()}
+		} else {
+			results[metric.Desc().String()] = result{counter: false, value: m.GetGauge().GetValue()}
+		}
+	}
+
+	require.Equal(t, map[string]result{
+		c.virtualStorageDeviceThroughput.String():           {counter: true, value: 10},
+		c.virtualStorageDeviceThroughputBytes.String():      {counter: true, value: 10 * 8192},
+		c.virtualStorageDeviceNormalizedThroughput.String(): {counter: false, value: 25},
+	}, results)
+
+	// An invalid Throughput counter skips the counters instead of reporting a reset.
+	ch = make(chan prometheus.Metric, 3)
+
+	c.collectVirtualStorageDeviceThroughput(ch, perfDataCounterValuesVirtualStorageDevice{
+		Name:                                     "disk.vhdx",
+		VirtualStorageDeviceThroughput:           -1,
+		VirtualStorageDeviceNormalizedThroughput: 25,
+	}, []string{"Throughput"})
+
+	close(ch)
+
+	var descs []string
+	for metric := range ch {
+		descs = append(descs, metric.Desc().String())
+	}
+
+	require.Equal(t, []string{c.virtualStorageDeviceNormalizedThroughput.String()}, descs)
+}
+
+func TestVirtualStorageDeviceLatencyOverhead(t *testing.T) {
+	t.Parallel()
+
+	require.InDelta(t, 0.003, virtualStorageDeviceLatencyOverhead(0.005, 0.002), 1e-9)
+	require.Zero(t, virtualStorageDeviceLatencyOverhead(0.002, 0.005))
+	require.Zero(t, virtualStorageDeviceLatencyOverhead(0, 0))
+}
+
+func TestParseTargetQueueDepths(t *testing.T) {
+	t.Parallel()
+
+	depths, err := parseTargetQueueDepths(32, []string{`D:\VMs=64`, `D:\VMs\Tier1\=8`, `e:/archive=2.5`})
+	require.NoError(t, err)
+	require.Equal(t, targetQueueDepths{
+		global: 32,
+		overrides: []targetQueueDepthOverride{
+			{path: `d:\vms\tier1`, prefix: "d:-vms-tier1", depth: 8},
+			{path: `e:\archive`, prefix: "e:-archive", depth: 2.5},
+			{path: `d:\vms`, prefix: "d:-vms", depth: 64},
+		},
+	}, depths)
+
+	for _, tc := range []struct {
+		name      string
+		global    float64
+		overrides []string
+	}{
+		{name: "negative global depth", global: -1},
+		{name: "infinite global depth", global: math.Inf(1)},
+		{name: "missing depth", overrides: []string{`D:\VMs`}},
+		{name: "empty path", overrides: []string{`\=8`}},
+		{name: "invalid depth", overrides: []string{`D:\VMs=deep`}},
+		{name: "zero depth", overrides: []string{`D:\VMs=0`}},
+	} {
+		_, err := parseTargetQueueDepths(tc.global, tc.overrides)
+		require.Error(t, err, tc.name)
+	}
+}
+
+func TestTargetQueueDepthsForDevice(t *testing.T) {
+	t.Parallel()
+
+	depths, err := parseTargetQueueDepths(32, []string{`D:\VMs=64`, `D:\VMs\Tier1=8`, `D:\VMs\vm01-disk.vhdx=4`})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		device   string
+		path     string
+		expected float64
+	}{
+		{`D:-VMs-Tier1-vm01-disk.vhdx`, ``, 8},
+		{`d:-vms-tier1-vm01-disk.vhdx`, ``, 8},
+		{`D:-VMs-Tier2-vm01-disk.vhdx`, ``, 64},
+		{`D:-VMs-vm01-disk.vhdx`, ``, 4},
+		{`D:-VMs2-vm01-disk.vhdx`, ``, 32},
+		{`C:-VMs-vm01-disk.vhdx`, ``, 32},
+		{`D:-VMs-Tier1-vm01-disk.vhdx`, `D:\VMs\Tier1\vm01-disk.vhdx`, 8},
+		{`D:-VMs-Tier1-vm01-disk.vhdx`, `d:/vms/tier1/vm01-disk.vhdx`, 8},
+		{`D:-VMs-vm01-disk.vhdx`, `D:\VMs\vm01-disk.vhdx`, 4},
+		{`D:-VMs-vm01-disk.vhdx`, `D:\VMs\vm01\disk.vhdx`, 64},
+		// The instance name of a disk below D:\VMs-old matches D:\VMs, the backing file path doesn't.
+		{`D:-VMs-old-disk.vhdx`, ``, 64},
+		{`D:-VMs-old-disk.vhdx`, `D:\VMs-old\disk.vhdx`, 32},
+	} {
+		require.Equal(t, tc.expected, depths.forDevice(tc.device, tc.path), tc.device, tc.path)
+	}
+
+	depths, err = parseTargetQueueDepths(0, nil)
+	require.NoError(t, err)
+	require.Zero(t, depths.forDevice(`D:-VMs-vm01-disk.vhdx`, `D:\VMs\vm01-disk.vhdx`))
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorVMMemory Hyper-V VM memory configuration
+type collectorVMMemory struct {
+	vmMemoryComputerSystemMIQuery mi.Query
+	vmMemorySettingMIQuery        mi.Query
+
+	vmMemoryConfigured *prometheus.Desc // Msvm_MemorySettingData.VirtualQuantity
+}
+
+// msvmMemorySettingDataQuantity represents the configured memory of a Msvm_MemorySettingData WMI instance.
+// VirtualQuantity is in megabytes.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-memorysettingdata
+type msvmMemorySettingDataQuantity struct {
+	InstanceID      string `mi:"InstanceID"`
+	VirtualQuantity uint64 `mi:"VirtualQuantity"`
+}
+
+func (c *Collector) buildVMMemory() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	c.vmMemoryComputerSystemMIQuery, err = mi.Select("Name", "ElementName").
+		From("Msvm_ComputerSystem").
+		WhereEqual("Caption", "Virtual Machine").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmMemorySettingMIQuery, err = mi.Select("InstanceID", "VirtualQuantity").From("Msvm_MemorySettingData").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmMemoryConfigured = bdf.NewDesc(
+		Name,
+		"virtual_machine_memory_configured_bytes",
+		"Represents the startup memory configured for the virtual machine.",
+		[]string{"vm"},
+	)
+
+	var dst []msvmMemorySettingDataQuantity
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.vmMemorySettingMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectVMMemory(ch chan<- prometheus.Metric) error {
+	var vms []msvmComputerSystemID
+	if err := c.miSession.Query(&vms, mi.NamespaceRootVirtualizationV2, c.vmMemoryComputerSystemMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var settings []msvmMemorySettingDataQuantity
+	if err := c.miSession.Query(&settings, mi.NamespaceRootVirtualizationV2, c.vmMemorySettingMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	vmNames := vmNamesByID(vms)
+
+	for _, setting := range settings {
+		vmName, ok := vmNameFromInstanceID(vmNames, setting.InstanceID)
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmMemoryConfigured,
+			prometheus.GaugeValue,
+			float64(setting.VirtualQuantity)*1024*1024,
+			vmName,
+		)
+	}
+
+	return nil
+}
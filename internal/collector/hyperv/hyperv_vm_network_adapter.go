@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ethernetPortEnabledStateDisabled is the Msvm_EthernetPortAllocationSettingData.EnabledState value
+// reported if the network adapter is disconnected from the switch.
+const ethernetPortEnabledStateDisabled = 3
+
+// collectorVMNetworkAdapter Hyper-V Virtual Network Adapter metrics labeled with VM, adapter name and MAC address
+type collectorVMNetworkAdapter struct {
+	vmNetworkAdapterComputerSystemMIQuery mi.Query
+	vmNetworkAdapterSettingsMIQuery       mi.Query
+	vmNetworkAdapterPortMIQuery           mi.Query
+
+	perfDataCollectorVMNetworkAdapter *pdh.Collector
+	perfDataObjectVMNetworkAdapter    []perfDataCounterValuesVMNetworkAdapter
+
+	vmNetworkAdapterInfo            *prometheus.Desc
+	vmNetworkAdapterBytesReceived   *prometheus.Desc // \Hyper-V Virtual Network Adapter(*)\Bytes Received/sec
+	vmNetworkAdapterBytesSent       *prometheus.Desc // \Hyper-V Virtual Network Adapter(*)\Bytes Sent/sec
+	vmNetworkAdapterPacketsReceived *prometheus.Desc // \Hyper-V Virtual Network Adapter(*)\Packets Received/sec
+	vmNetworkAdapterPacketsSent     *prometheus.Desc // \Hyper-V Virtual Network Adapter(*)\Packets Sent/sec
+}
+
+type perfDataCounterValuesVMNetworkAdapter struct {
+	Name string
+
+	BytesReceived   float64 `perfdata:"Bytes Received/sec"`
+	BytesSent       float64 `perfdata:"Bytes Sent/sec"`
+	PacketsReceived float64 `perfdata:"Packets Received/sec"`
+	PacketsSent     float64 `perfdata:"Packets Sent/sec"`
+}
+
+// msvmSyntheticEthernetPortSettingData represents the Msvm_SyntheticEthernetPortSettingData WMI class.
+// The InstanceID has the format Microsoft:<VM ID>\<adapter ID>.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-syntheticethernetportsettingdata
+type msvmSyntheticEthernetPortSettingData struct {
+	InstanceID  string `mi:"InstanceID"`
+	ElementName string `mi:"ElementName"`
+	Address     string `mi:"Address"`
+}
+
+// msvmEthernetPortAllocationSettingData represents the Msvm_EthernetPortAllocationSettingData WMI class.
+// The InstanceID has the format Microsoft:<VM ID>\<adapter ID>\<suffix>.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-ethernetportallocationsettingdata
+type msvmEthernetPortAllocationSettingData struct {
+	InstanceID          string `mi:"InstanceID"`
+	EnabledState        uint16 `mi:"EnabledState"`
+	LastKnownSwitchName string `mi:"LastKnownSwitchName"`
+}
+
+type vmNetworkAdapter struct {
+	vmName      string
+	adapterName string
+	mac         string
+	switchName  string
+}
+
+func (c *Collector) buildVMNetworkAdapter() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	c.vmNetworkAdapterComputerSystemMIQuery, err = mi.NewQuery("SELECT Name, ElementName FROM Msvm_ComputerSystem WHERE Caption = 'Virtual Machine'")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmNetworkAdapterSettingsMIQuery, err = mi.NewQuery("SELECT InstanceID, ElementName, Address FROM Msvm_SyntheticEthernetPortSettingData")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmNetworkAdapterPortMIQuery, err = mi.NewQuery("SELECT InstanceID, EnabledState, LastKnownSwitchName FROM Msvm_EthernetPortAllocationSettingData")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.perfDataCollectorVMNetworkAdapter, err = pdh.NewCollector[perfDataCounterValuesVMNetworkAdapter](c.logger, pdh.CounterTypeRaw, "Hyper-V Virtual Network Adapter", pdh.InstancesAll, c.pdhOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create Hyper-V Virtual Network Adapter collector: %w", err)
+	}
+
+	c.vmNetworkAdapterInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "vm_network_adapter_info"),
+		"Represents the virtual network adapter of a virtual machine. The switch label is empty if the adapter is not connected.",
+		[]string{"vm_name", "adapter_name", "mac", "switch"},
+		nil,
+	)
+	c.vmNetworkAdapterBytesReceived = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "vm_network_adapter_received_bytes_total"),
+		"Represents the total number of bytes received by the virtual network adapter",
+		[]string{"vm_name", "adapter_name", "mac"},
+		nil,
+	)
+	c.vmNetworkAdapterBytesSent = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "vm_network_adapter_sent_bytes_total"),
+		"Represents the total number of bytes sent by the virtual network adapter",
+		[]string{"vm_name", "adapter_name", "mac"},
+		nil,
+	)
+	c.vmNetworkAdapterPacketsReceived = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "vm_network_adapter_received_packets_total"),
+		"Represents the total number of packets received by the virtual network adapter",
+		[]string{"vm_name", "adapter_name", "mac"},
+		nil,
+	)
+	c.vmNetworkAdapterPacketsSent = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "vm_network_adapter_sent_packets_total"),
+		"Represents the total number of packets sent by the virtual network adapter",
+		[]string{"vm_name", "adapter_name", "mac"},
+		nil,
+	)
+
+	var dst []msvmSyntheticEthernetPortSettingData
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.vmNetworkAdapterSettingsMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) closeVMNetworkAdapter() {
+	c.perfDataCollectorVMNetworkAdapter.Close()
+}
+
+// vmNetworkAdapters returns the virtual network adapters of all VMs, keyed by <VM ID>--<adapter ID>.
+func (c *Collector) vmNetworkAdapters() (map[string]vmNetworkAdapter, error) {
+	var vms []msvmComputerSystemID
+	if err := c.miSession.Query(&vms, mi.NamespaceRootVirtualizationV2, c.vmNetworkAdapterComputerSystemMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var settings []msvmSyntheticEthernetPortSettingData
+	if err := c.miSession.Query(&settings, mi.NamespaceRootVirtualizationV2, c.vmNetworkAdapterSettingsMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var ports []msvmEthernetPortAllocationSettingData
+	if err := c.miSession.Query(&ports, mi.NamespaceRootVirtualizationV2, c.vmNetworkAdapterPortMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	vmNames := vmNamesByID(vms)
+
+	switchNames := make(map[string]string, len(ports))
+
+	for _, port := range ports {
+		key, ok := vmNetworkAdapterKeyFromInstanceID(port.InstanceID)
+		if !ok || port.EnabledState == ethernetPortEnabledStateDisabled {
+			continue
+		}
+
+		switchNames[key] = port.LastKnownSwitchName
+	}
+
+	adapters := make(map[string]vmNetworkAdapter, len(settings))
+
+	for _, setting := range settings {
+		key, ok := vmNetworkAdapterKeyFromInstanceID(setting.InstanceID)
+		if !ok {
+			continue
+		}
+
+		vmName, ok := vmNameFromInstanceID(vmNames, setting.InstanceID)
+		if !ok {
+			continue
+		}
+
+		adapters[key] = vmNetworkAdapter{
+			vmName:      vmName,
+			adapterName: setting.ElementName,
+			mac:         formatMACAddress(setting.Address),
+			switchName:  switchNames[key],
+		}
+	}
+
+	return adapters, nil
+}
+
+func (c *Collector) collectVMNetworkAdapter(ch chan<- prometheus.Metric) error {
+	adapters, err := c.vmNetworkAdapters()
+	if err != nil {
+		return err
+	}
+
+	for _, adapter := range adapters {
+		ch <- prometheus.MustNewConstMetric(
+			c.vmNetworkAdapterInfo,
+			prometheus.GaugeValue,
+			1,
+			adapter.vmName,
+			adapter.adapterName,
+			adapter.mac,
+			adapter.switchName,
+		)
+	}
+
+	if err := c.perfDataCollectorVMNetworkAdapter.Collect(&c.perfDataObjectVMNetworkAdapter); err != nil {
+		return fmt.Errorf("failed to collect Hyper-V Virtual Network Adapter metrics: %w", err)
+	}
+
+	for _, data := range c.perfDataObjectVMNetworkAdapter {
+		key, ok := vmNetworkAdapterKeyFromPerfInstance(data.Name)
+		if !ok {
+			continue
+		}
+
+		adapter, ok := adapters[key]
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmNetworkAdapterBytesReceived,
+			prometheus.CounterValue,
+			data.BytesReceived,
+			adapter.vmName, adapter.adapterName, adapter.mac,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmNetworkAdapterBytesSent,
+			prometheus.CounterValue,
+			data.BytesSent,
+			adapter.vmName, adapter.adapterName, adapter.mac,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmNetworkAdapterPacketsReceived,
+			prometheus.CounterValue,
+			data.PacketsReceived,
+			adapter.vmName, adapter.adapterName, adapter.mac,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmNetworkAdapterPacketsSent,
+			prometheus.CounterValue,
+			data.PacketsSent,
+			adapter.vmName, adapter.adapterName, adapter.mac,
+		)
+	}
+
+	return nil
+}
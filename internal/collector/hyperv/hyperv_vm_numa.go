@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorVMNUMA Hyper-V VM NUMA node assignment
+type collectorVMNUMA struct {
+	vmNUMAMIQuery mi.Query
+
+	vmNUMAHomeNode    *prometheus.Desc // Msvm_VirtualSystemSettingData.NumaNodeList
+	vmNUMASpanAllowed *prometheus.Desc // Msvm_VirtualSystemSettingData.NumaNodeList has no or more than one node
+}
+
+// msvmVirtualSystemSettingDataNUMA represents the NUMA nodes of a Msvm_VirtualSystemSettingData WMI instance.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-virtualsystemsettingdata
+type msvmVirtualSystemSettingDataNUMA struct {
+	ElementName  string   `mi:"ElementName"`
+	NumaNodeList []uint32 `mi:"NumaNodeList"`
+}
+
+func (c *Collector) buildVMNUMA() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	// Only the realized settings belong to the VM itself, the other settings belong to snapshots.
+	c.vmNUMAMIQuery, err = mi.Select("ElementName", "NumaNodeList").
+		From("Msvm_VirtualSystemSettingData").
+		WhereEqual("VirtualSystemType", "Microsoft:Hyper-V:System:Realized").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmNUMAHomeNode = bdf.NewDesc(
+		Name,
+		"virtual_machine_numa_home_node",
+		"Represents a NUMA node the virtual machine is allowed to use. Always 1.",
+		[]string{"vm", "numa_node"},
+	)
+	c.vmNUMASpanAllowed = bdf.NewDesc(
+		Name,
+		"virtual_machine_numa_span_allowed",
+		"Represents whether the virtual machine is allowed to span NUMA nodes (1) or is restricted to a single NUMA node (0).",
+		[]string{"vm"},
+	)
+
+	var dst []msvmVirtualSystemSettingDataNUMA
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.vmNUMAMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectVMNUMA(ch chan<- prometheus.Metric) error {
+	var settings []msvmVirtualSystemSettingDataNUMA
+	if err := c.miSession.Query(&settings, mi.NamespaceRootVirtualizationV2, c.vmNUMAMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	for _, setting := range settings {
+		for _, node := range setting.NumaNodeList {
+			ch <- prometheus.MustNewConstMetric(
+				c.vmNUMAHomeNode,
+				prometheus.GaugeValue,
+				1,
+				setting.ElementName,
+				strconv.FormatUint(uint64(node), 10),
+			)
+		}
+
+		// An empty node list doesn't restrict the VM to any NUMA node.
+		spanAllowed := 0.0
+		if len(setting.NumaNodeList) != 1 {
+			spanAllowed = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmNUMASpanAllowed,
+			prometheus.GaugeValue,
+			spanAllowed,
+			setting.ElementName,
+		)
+	}
+
+	return nil
+}
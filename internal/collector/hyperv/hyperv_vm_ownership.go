@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/sysinfoapi"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// vmOwnershipCacheTTL is the interval in which the cluster resource owners are refreshed.
+const vmOwnershipCacheTTL = 30 * time.Second
+
+// collectorVMOwnership Hyper-V VM ownership in failover clusters
+type collectorVMOwnership struct {
+	vmOwnershipVMMIQuery      mi.Query
+	vmOwnershipClusterMIQuery mi.Query
+
+	vmOwnershipNodeName string
+
+	// vmOwnershipMu guards the cached cluster resource owners, since concurrent scrapes may refresh them.
+	vmOwnershipMu          sync.Mutex
+	vmOwnershipOwners      map[string]string
+	vmOwnershipLastRefresh time.Time
+
+	vmOwned *prometheus.Desc // MSCluster_Resource.OwnerNode compared with the local node name
+}
+
+// msvmComputerSystemName represents the name of a Msvm_ComputerSystem WMI instance
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-computersystem
+type msvmComputerSystemName struct {
+	ElementName string `mi:"ElementName"`
+}
+
+// msClusterVirtualMachineResource represents a virtual machine resource of the MSCluster_Resource WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/cluswmi/mscluster-resource
+type msClusterVirtualMachineResource struct {
+	Name      string `mi:"Name"`
+	OwnerNode string `mi:"OwnerNode"`
+}
+
+func (c *Collector) buildVMOwnership() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	vmOwnershipVMMIQuery, err := mi.Select("ElementName").
+		From("Msvm_ComputerSystem").
+		WhereEqual("Caption", "Virtual Machine").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	vmOwnershipClusterMIQuery, err := mi.Select("Name", "OwnerNode").
+		From("MSCluster_Resource").
+		WhereEqual("Type", "Virtual Machine").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	nodeName, err := sysinfoapi.GetComputerName(sysinfoapi.ComputerNamePhysicalNetBIOS)
+	if err != nil {
+		return fmt.Errorf("failed to get computer name: %w", err)
+	}
+
+	c.vmOwnershipVMMIQuery = vmOwnershipVMMIQuery
+	c.vmOwnershipClusterMIQuery = vmOwnershipClusterMIQuery
+	c.vmOwnershipNodeName = nodeName
+
+	c.vmOwned = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "vm_owned"),
+		"Represents whether the virtual machine is owned by this node (1) or by another failover cluster node (0). Always 1 on standalone hosts.",
+		[]string{"vm"},
+		nil,
+	)
+
+	var dst []msvmComputerSystemName
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.vmOwnershipVMMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectVMOwnership(ch chan<- prometheus.Metric) error {
+	var vms []msvmComputerSystemName
+	if err := c.miSession.Query(&vms, mi.NamespaceRootVirtualizationV2, c.vmOwnershipVMMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	owners := c.getVMOwners()
+
+	for _, vm := range vms {
+		owned := 1.0
+
+		// VMs without cluster resource are not clustered, and therefore always owned by this node.
+		if ownerNode, ok := owners[vm.ElementName]; ok && !strings.EqualFold(ownerNode, c.vmOwnershipNodeName) {
+			owned = 0.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmOwned,
+			prometheus.GaugeValue,
+			owned,
+			vm.ElementName,
+		)
+	}
+
+	return nil
+}
+
+// getVMOwners returns the owner node of each clustered VM, keyed by the VM name of the cluster resource.
+// The owner group can't be used, since it's named differently from the VM, e.g. by SCVMM or after a rename.
+// The result is cached for vmOwnershipCacheTTL. If the cluster can't be queried, e.g. on standalone hosts
+// or while the cluster service is restarting, the last known owners are returned.
+func (c *Collector) getVMOwners() map[string]string {
+	c.vmOwnershipMu.Lock()
+	defer c.vmOwnershipMu.Unlock()
+
+	if time.Since(c.vmOwnershipLastRefresh) < vmOwnershipCacheTTL {
+		return c.vmOwnershipOwners
+	}
+
+	c.vmOwnershipLastRefresh = time.Now()
+
+	var dst []msClusterVirtualMachineResource
+	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.vmOwnershipClusterMIQuery); err != nil {
+		c.logger.Debug("failed to query cluster virtual machine resources, using last known owners",
+			slog.Any("err", err),
+		)
+
+		return c.vmOwnershipOwners
+	}
+
+	owners := make(map[string]string, len(dst))
+
+	for _, resource := range dst {
+		owners[clusterVMName(resource.Name)] = resource.OwnerNode
+	}
+
+	c.vmOwnershipOwners = owners
+
+	return c.vmOwnershipOwners
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorVMRemoting Hyper-V VM Remoting metrics
+type collectorVMRemoting struct {
+	perfDataCollectorVMRemoting *pdh.Collector
+	perfDataObjectVMRemoting    []perfDataCounterValuesVMRemoting
+
+	vmConsoleConnections *prometheus.Desc // \Hyper-V VM Remoting(*)\Connected Clients
+}
+
+type perfDataCounterValuesVMRemoting struct {
+	Name string
+
+	ConnectedClients float64 `perfdata:"Connected Clients"`
+}
+
+func (c *Collector) buildVMRemoting() error {
+	var err error
+
+	c.perfDataCollectorVMRemoting, err = pdh.NewCollector[perfDataCounterValuesVMRemoting](c.logger, pdh.CounterTypeRaw, "Hyper-V VM Remoting", pdh.InstancesAll, c.pdhOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create Hyper-V VM Remoting collector: %w", err)
+	}
+
+	c.vmConsoleConnections = bdf.NewDesc(
+		Name,
+		"virtual_machine_console_connections",
+		"Represents the number of clients connected to the console of the virtual machine, e.g. through VMConnect.",
+		[]string{"vm"},
+	)
+
+	return nil
+}
+
+func (c *Collector) collectVMRemoting(ch chan<- prometheus.Metric) error {
+	err := c.perfDataCollectorVMRemoting.Collect(&c.perfDataObjectVMRemoting)
+	if err != nil {
+		return fmt.Errorf("failed to collect Hyper-V VM Remoting metrics: %w", err)
+	}
+
+	for _, data := range c.perfDataObjectVMRemoting {
+		ch <- prometheus.MustNewConstMetric(
+			c.vmConsoleConnections,
+			prometheus.GaugeValue,
+			data.ConnectedClients,
+			data.Name,
+		)
+	}
+
+	return nil
+}
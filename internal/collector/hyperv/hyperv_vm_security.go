@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// vmSecurityRefreshInterval is the interval in which the VM security settings are refreshed.
+// The settings change rarely and enumerating them is expensive.
+const vmSecurityRefreshInterval = 5 * time.Minute
+
+// virtualSystemSubTypeGeneration1 is the Msvm_VirtualSystemSettingData.VirtualSystemSubType of generation 1 VMs.
+const virtualSystemSubTypeGeneration1 = "Microsoft:Hyper-V:SubType:1"
+
+// collectorVMSecurity Hyper-V VM security settings
+type collectorVMSecurity struct {
+	vmSecuritySystemMIQuery   mi.Query
+	vmSecuritySettingsMIQuery mi.Query
+
+	vmSecurityMu          sync.Mutex
+	vmSecurityCache       []vmSecurity
+	vmSecurityLastRefresh time.Time
+
+	vmSecurityInfo *prometheus.Desc
+}
+
+type vmSecurity struct {
+	vmName                   string
+	secureBoot               string
+	tpmEnabled               string
+	shielded                 string
+	encryptStateAndMigration string
+}
+
+// msvmVirtualSystemSettingData represents the Msvm_VirtualSystemSettingData WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-virtualsystemsettingdata
+type msvmVirtualSystemSettingData struct {
+	VirtualSystemIdentifier string `mi:"VirtualSystemIdentifier"`
+	ElementName             string `mi:"ElementName"`
+	VirtualSystemSubType    string `mi:"VirtualSystemSubType"`
+	SecureBootEnabled       bool   `mi:"SecureBootEnabled"`
+}
+
+// msvmSecuritySettingData represents the Msvm_SecuritySettingData WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-securitysettingdata
+type msvmSecuritySettingData struct {
+	InstanceID                        string `mi:"InstanceID"`
+	TpmEnabled                        bool   `mi:"TpmEnabled"`
+	ShieldingRequested                bool   `mi:"ShieldingRequested"`
+	EncryptStateAndVmMigrationTraffic bool   `mi:"EncryptStateAndVmMigrationTraffic"`
+}
+
+func (c *Collector) buildVMSecurity() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	c.vmSecuritySystemMIQuery, err = mi.Select("VirtualSystemIdentifier", "ElementName", "VirtualSystemSubType", "SecureBootEnabled").
+		From("Msvm_VirtualSystemSettingData").
+		WhereEqual("VirtualSystemType", "Microsoft:Hyper-V:System:Realized").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmSecuritySettingsMIQuery, err = mi.Select("InstanceID", "TpmEnabled", "ShieldingRequested", "EncryptStateAndVmMigrationTraffic").
+		From("Msvm_SecuritySettingData").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmSecurityInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "vm_security_info"),
+		"Represents the security settings of the virtual machine. secure_boot is \"unsupported\" for generation 1 VMs.",
+		[]string{"vm", "secure_boot", "tpm_enabled", "shielded", "encrypt_state_and_migration"},
+		nil,
+	)
+
+	if _, err := c.queryVMSecurity(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Collector) collectVMSecurity(ch chan<- prometheus.Metric) error {
+	c.vmSecurityMu.Lock()
+	defer c.vmSecurityMu.Unlock()
+
+	if time.Since(c.vmSecurityLastRefresh) >= vmSecurityRefreshInterval {
+		vms, err := c.queryVMSecurity()
+		if err != nil {
+			if c.vmSecurityCache == nil {
+				return err
+			}
+
+			c.logger.Warn("failed to refresh Hyper-V VM security settings, using last known settings",
+				slog.Any("err", err),
+			)
+		} else {
+			c.vmSecurityCache = vms
+			c.vmSecurityLastRefresh = time.Now()
+		}
+	}
+
+	for _, vm := range c.vmSecurityCache {
+		ch <- prometheus.MustNewConstMetric(
+			c.vmSecurityInfo,
+			prometheus.GaugeValue,
+			1,
+			vm.vmName,
+			vm.secureBoot,
+			vm.tpmEnabled,
+			vm.shielded,
+			vm.encryptStateAndMigration,
+		)
+	}
+
+	return nil
+}
+
+func (c *Collector) queryVMSecurity() ([]vmSecurity, error) {
+	var systems []msvmVirtualSystemSettingData
+	if err := c.miSession.Query(&systems, mi.NamespaceRootVirtualizationV2, c.vmSecuritySystemMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var settings []msvmSecuritySettingData
+	if err := c.miSession.Query(&settings, mi.NamespaceRootVirtualizationV2, c.vmSecuritySettingsMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	securitySettings := make(map[string]msvmSecuritySettingData, len(settings))
+
+	for _, setting := range settings {
+		// The InstanceID has the format Microsoft:<VM ID>[\<setting ID>].
+		vmID, ok := strings.CutPrefix(setting.InstanceID, "Microsoft:")
+		if !ok {
+			continue
+		}
+
+		vmID, _, _ = strings.Cut(vmID, `\`)
+		securitySettings[strings.ToUpper(vmID)] = setting
+	}
+
+	vms := make([]vmSecurity, 0, len(systems))
+
+	for _, system := range systems {
+		setting := securitySettings[strings.ToUpper(system.VirtualSystemIdentifier)]
+
+		secureBoot := strconv.FormatBool(system.SecureBootEnabled)
+		if system.VirtualSystemSubType == virtualSystemSubTypeGeneration1 {
+			secureBoot = "unsupported"
+		}
+
+		vms = append(vms, vmSecurity{
+			vmName:                   system.ElementName,
+			secureBoot:               secureBoot,
+			tpmEnabled:               strconv.FormatBool(setting.TpmEnabled),
+			shielded:                 strconv.FormatBool(setting.ShieldingRequested),
+			encryptStateAndMigration: strconv.FormatBool(setting.EncryptStateAndVmMigrationTraffic),
+		})
+	}
+
+	return vms, nil
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorVMVCPU Hyper-V VM virtual processor configuration
+type collectorVMVCPU struct {
+	vmVCPUComputerSystemMIQuery mi.Query
+	vmVCPUProcessorMIQuery      mi.Query
+
+	vmVCPUCount *prometheus.Desc // Msvm_ProcessorSettingData.VirtualQuantity
+}
+
+// msvmProcessorSettingDataQuantity represents the number of virtual processors of a Msvm_ProcessorSettingData WMI instance.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-processorsettingdata
+type msvmProcessorSettingDataQuantity struct {
+	InstanceID      string `mi:"InstanceID"`
+	VirtualQuantity uint64 `mi:"VirtualQuantity"`
+}
+
+func (c *Collector) buildVMVCPU() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	c.vmVCPUComputerSystemMIQuery, err = mi.Select("Name", "ElementName").
+		From("Msvm_ComputerSystem").
+		WhereEqual("Caption", "Virtual Machine").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmVCPUProcessorMIQuery, err = mi.Select("InstanceID", "VirtualQuantity").From("Msvm_ProcessorSettingData").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmVCPUCount = bdf.NewDesc(
+		Name,
+		"virtual_machine_vcpu_count",
+		"Represents the number of virtual processors configured for the virtual machine.",
+		[]string{"vm"},
+	)
+
+	var dst []msvmProcessorSettingDataQuantity
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.vmVCPUProcessorMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectVMVCPU(ch chan<- prometheus.Metric) error {
+	var vms []msvmComputerSystemID
+	if err := c.miSession.Query(&vms, mi.NamespaceRootVirtualizationV2, c.vmVCPUComputerSystemMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var settings []msvmProcessorSettingDataQuantity
+	if err := c.miSession.Query(&settings, mi.NamespaceRootVirtualizationV2, c.vmVCPUProcessorMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	vmNames := vmNamesByID(vms)
+
+	for _, setting := range settings {
+		vmName, ok := vmNameFromInstanceID(vmNames, setting.InstanceID)
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vmVCPUCount,
+			prometheus.GaugeValue,
+			float64(setting.VirtualQuantity),
+			vmName,
+		)
+	}
+
+	return nil
+}
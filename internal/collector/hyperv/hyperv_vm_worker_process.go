@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
+	"github.com/prometheus-community/windows_exporter/internal/headers/psapi"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+// collectorVMWorkerProcess Hyper-V VM worker process (vmwp.exe) resource usage
+type collectorVMWorkerProcess struct {
+	vmWorkerProcessMIQuery mi.Query
+
+	vmWorkerProcessCPUTime      *prometheus.Desc // GetProcessTimes, kernel + user time
+	vmWorkerProcessWorkingSet   *prometheus.Desc // PROCESS_MEMORY_COUNTERS_EX.WorkingSetSize
+	vmWorkerProcessPrivateBytes *prometheus.Desc // PROCESS_MEMORY_COUNTERS_EX.PrivateUsage
+	vmWorkerProcessHandles      *prometheus.Desc // GetProcessHandleCount
+}
+
+// msvmComputerSystemProcess represents the worker process of a Msvm_ComputerSystem WMI instance.
+// ProcessID is the PID of the vmwp.exe process of the VM and 0 if the VM is not running.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-computersystem
+type msvmComputerSystemProcess struct {
+	ElementName string `mi:"ElementName"`
+	ProcessID   uint32 `mi:"ProcessID"`
+}
+
+func (c *Collector) buildVMWorkerProcess() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	c.vmWorkerProcessMIQuery, err = mi.Select("ElementName", "ProcessID").
+		From("Msvm_ComputerSystem").
+		WhereEqual("Caption", "Virtual Machine").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.vmWorkerProcessCPUTime = bdf.NewDesc(
+		Name,
+		"vm_worker_process_cpu_time_seconds_total",
+		"Represents the CPU time spent by the worker process of the virtual machine in kernel and user mode.",
+		[]string{"vm_name"},
+	)
+	c.vmWorkerProcessWorkingSet = bdf.NewDesc(
+		Name,
+		"vm_worker_process_working_set_bytes",
+		"Represents the working set of the worker process of the virtual machine.",
+		[]string{"vm_name"},
+	)
+	c.vmWorkerProcessPrivateBytes = bdf.NewDesc(
+		Name,
+		"vm_worker_process_private_bytes",
+		"Represents the private memory committed by the worker process of the virtual machine.",
+		[]string{"vm_name"},
+	)
+	c.vmWorkerProcessHandles = bdf.NewDesc(
+		Name,
+		"vm_worker_process_handles",
+		"Represents the number of open handles of the worker process of the virtual machine.",
+		[]string{"vm_name"},
+	)
+
+	var dst []msvmComputerSystemProcess
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.vmWorkerProcessMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectVMWorkerProcess(ch chan<- prometheus.Metric) error {
+	var vms []msvmComputerSystemProcess
+	if err := c.miSession.Query(&vms, mi.NamespaceRootVirtualizationV2, c.vmWorkerProcessMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	for _, vm := range vms {
+		if vm.ProcessID == 0 {
+			continue
+		}
+
+		// A failure on one worker process, e.g. access denied on a protected process
+		// or a VM that stopped since the WMI query, must not fail the other VMs.
+		if err := c.collectVMWorkerProcessMetrics(ch, vm); err != nil {
+			c.logger.Debug("failed to collect Hyper-V VM worker process metrics",
+				slog.String("vm_name", vm.ElementName),
+				slog.Uint64("pid", uint64(vm.ProcessID)),
+				slog.Any("err", err),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) collectVMWorkerProcessMetrics(ch chan<- prometheus.Metric, vm msvmComputerSystemProcess) error {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, vm.ProcessID)
+	if err != nil {
+		return fmt.Errorf("failed to open process: %w", err)
+	}
+
+	defer func() {
+		_ = windows.CloseHandle(handle)
+	}()
+
+	var creation, exit, kernel, user windows.Filetime
+
+	if err := windows.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return fmt.Errorf("failed to get process times: %w", err)
+	}
+
+	memory, err := psapi.GetProcessMemoryInfo(handle)
+	if err != nil {
+		return fmt.Errorf("failed to get process memory info: %w", err)
+	}
+
+	handles, err := kernel32.GetProcessHandleCount(handle)
+	if err != nil {
+		return fmt.Errorf("failed to get process handle count: %w", err)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.vmWorkerProcessCPUTime,
+		prometheus.CounterValue,
+		float64(filetimeTicks(kernel)+filetimeTicks(user))*pdh.TicksToSecondScaleFactor,
+		vm.ElementName,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.vmWorkerProcessWorkingSet,
+		prometheus.GaugeValue,
+		float64(memory.WorkingSetSize),
+		vm.ElementName,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.vmWorkerProcessPrivateBytes,
+		prometheus.GaugeValue,
+		float64(memory.PrivateUsage),
+		vm.ElementName,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.vmWorkerProcessHandles,
+		prometheus.GaugeValue,
+		float64(handles),
+		vm.ElementName,
+	)
+
+	return nil
+}
+
+// filetimeTicks returns a FILETIME duration in 100-nanosecond ticks.
+func filetimeTicks(ft windows.Filetime) uint64 {
+	return uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime)
+}
@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// lbfoTeamMemberModeActive and lbfoTeamMemberModeStandby are the MSFT_NetLbfoTeamMember.OperationalMode values.
+	lbfoTeamMemberModeActive  = 0
+	lbfoTeamMemberModeStandby = 1
+
+	// lbfoTeamMemberStatusFailed is the MSFT_NetLbfoTeamMember.OperationalStatus of a failed member.
+	lbfoTeamMemberStatusFailed = 2
+)
+
+// collectorVSwitchTeam active and standby members of the NIC teams bound to virtual switches
+type collectorVSwitchTeam struct {
+	vSwitchTeamMemberMIQuery           mi.Query
+	vSwitchTeamNicMIQuery              mi.Query
+	vSwitchTeamSwitchMIQuery           mi.Query
+	vSwitchTeamExternalPortMIQuery     mi.Query
+	vSwitchTeamSAPMIQuery              mi.Query
+	vSwitchTeamActiveConnectionMIQuery mi.Query
+
+	vSwitchTeamActiveMemberCount  *prometheus.Desc // MSFT_NetLbfoTeamMember.OperationalMode = Active
+	vSwitchTeamStandbyMemberCount *prometheus.Desc // MSFT_NetLbfoTeamMember.OperationalMode = Standby
+}
+
+// msftNetLbfoTeamMember represents the MSFT_NetLbfoTeamMember WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/ndisimplatcimprov/msft-netlbfoteammember
+type msftNetLbfoTeamMember struct {
+	Team              string `mi:"Team"`
+	OperationalMode   uint32 `mi:"OperationalMode"`
+	OperationalStatus uint32 `mi:"OperationalStatus"`
+}
+
+// msftNetLbfoTeamNic represents the MSFT_NetLbfoTeamNic WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/ndisimplatcimprov/msft-netlbfoteamnic
+type msftNetLbfoTeamNic struct {
+	Team                 string `mi:"Team"`
+	InterfaceDescription string `mi:"InterfaceDescription"`
+}
+
+// msvmVirtualEthernetSwitch represents the Msvm_VirtualEthernetSwitch WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-virtualethernetswitch
+type msvmVirtualEthernetSwitch struct {
+	Name        string `mi:"Name"`
+	ElementName string `mi:"ElementName"`
+}
+
+// msvmExternalEthernetPort represents the Msvm_ExternalEthernetPort WMI class.
+// The ElementName is the interface description of the physical or team network adapter.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-externalethernetport
+type msvmExternalEthernetPort struct {
+	DeviceID    string `mi:"DeviceID"`
+	ElementName string `mi:"ElementName"`
+}
+
+// msvmEthernetDeviceSAPImplementation represents the Msvm_EthernetDeviceSAPImplementation WMI class,
+// which associates an ethernet port with its LAN endpoint.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-ethernetdevicesapimplementation
+type msvmEthernetDeviceSAPImplementation struct {
+	Antecedent struct {
+		DeviceID string `mi:"DeviceID"`
+	} `mi:"Antecedent"`
+	Dependent msvmLANEndpointReference `mi:"Dependent"`
+}
+
+// msvmActiveConnection represents the Msvm_ActiveConnection WMI class,
+// which connects the LAN endpoint of an ethernet port with the LAN endpoint of a switch port.
+// - https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-activeconnection
+type msvmActiveConnection struct {
+	Antecedent msvmLANEndpointReference `mi:"Antecedent"`
+	Dependent  msvmLANEndpointReference `mi:"Dependent"`
+}
+
+// msvmLANEndpointReference represents the key properties of a Msvm_LANEndpoint reference.
+// The SystemName of the LAN endpoint of a switch port is the name of the virtual switch.
+type msvmLANEndpointReference struct {
+	Name       string `mi:"Name"`
+	SystemName string `mi:"SystemName"`
+}
+
+type vSwitchTeamMemberCount struct {
+	active  float64
+	standby float64
+}
+
+func (c *Collector) buildVSwitchTeam() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	for _, q := range []struct {
+		dst   *mi.Query
+		query *mi.QueryBuilder
+	}{
+		{&c.vSwitchTeamMemberMIQuery, mi.Select("Team", "OperationalMode", "OperationalStatus").From("MSFT_NetLbfoTeamMember")},
+		{&c.vSwitchTeamNicMIQuery, mi.Select("Team", "InterfaceDescription").From("MSFT_NetLbfoTeamNic")},
+		{&c.vSwitchTeamSwitchMIQuery, mi.Select("Name", "ElementName").From("Msvm_VirtualEthernetSwitch")},
+		{&c.vSwitchTeamExternalPortMIQuery, mi.Select("DeviceID", "ElementName").From("Msvm_ExternalEthernetPort")},
+		{&c.vSwitchTeamSAPMIQuery, mi.Select("Antecedent", "Dependent").From("Msvm_EthernetDeviceSAPImplementation")},
+		{&c.vSwitchTeamActiveConnectionMIQuery, mi.Select("Antecedent", "Dependent").From("Msvm_ActiveConnection")},
+	} {
+		query, err := q.query.Build()
+		if err != nil {
+			return fmt.Errorf("failed to create WMI query: %w", err)
+		}
+
+		*q.dst = query
+	}
+
+	c.vSwitchTeamActiveMemberCount = bdf.NewDesc(
+		Name,
+		"vswitch_team_active_member_count",
+		"Represents the number of active members of the NIC team. Failed members are not counted.",
+		[]string{"switch", "team"},
+	)
+	c.vSwitchTeamStandbyMemberCount = bdf.NewDesc(
+		Name,
+		"vswitch_team_standby_member_count",
+		"Represents the number of standby members of the NIC team. Failed members are not counted.",
+		[]string{"switch", "team"},
+	)
+
+	var dst []msftNetLbfoTeamMember
+	if err := c.miSession.Query(&dst, mi.NamespaceRootStandardCimv2, c.vSwitchTeamMemberMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectVSwitchTeam(ch chan<- prometheus.Metric) error {
+	var members []msftNetLbfoTeamMember
+	if err := c.miSession.Query(&members, mi.NamespaceRootStandardCimv2, c.vSwitchTeamMemberMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	if len(members) == 0 {
+		return nil
+	}
+
+	switchNames, err := c.vSwitchTeamSwitchNames()
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]vSwitchTeamMemberCount)
+
+	for _, member := range members {
+		count := counts[member.Team]
+
+		if member.OperationalStatus != lbfoTeamMemberStatusFailed {
+			switch member.OperationalMode {
+			case lbfoTeamMemberModeActive:
+				count.active++
+			case lbfoTeamMemberModeStandby:
+				count.standby++
+			}
+		}
+
+		counts[member.Team] = count
+	}
+
+	for team, count := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			c.vSwitchTeamActiveMemberCount,
+			prometheus.GaugeValue,
+			count.active,
+			switchNames[team],
+			team,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.vSwitchTeamStandbyMemberCount,
+			prometheus.GaugeValue,
+			count.standby,
+			switchNames[team],
+			team,
+		)
+	}
+
+	return nil
+}
+
+// vSwitchTeamSwitchNames returns the name of the virtual switch each NIC team is bound to, keyed by team name.
+func (c *Collector) vSwitchTeamSwitchNames() (map[string]string, error) {
+	var teamNics []msftNetLbfoTeamNic
+	if err := c.miSession.Query(&teamNics, mi.NamespaceRootStandardCimv2, c.vSwitchTeamNicMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var switches []msvmVirtualEthernetSwitch
+	if err := c.miSession.Query(&switches, mi.NamespaceRootVirtualizationV2, c.vSwitchTeamSwitchMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var externalPorts []msvmExternalEthernetPort
+	if err := c.miSession.Query(&externalPorts, mi.NamespaceRootVirtualizationV2, c.vSwitchTeamExternalPortMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var saps []msvmEthernetDeviceSAPImplementation
+	if err := c.miSession.Query(&saps, mi.NamespaceRootVirtualizationV2, c.vSwitchTeamSAPMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var connections []msvmActiveConnection
+	if err := c.miSession.Query(&connections, mi.NamespaceRootVirtualizationV2, c.vSwitchTeamActiveConnectionMIQuery); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return teamSwitchNames(teamNics, switches, externalPorts, saps, connections), nil
+}
+
+// teamSwitchNames joins the team network adapters with the virtual switches. The team network adapter is the
+// external ethernet port of the switch, whose LAN endpoint is connected to the LAN endpoint of a switch port.
+// Teams which are not bound to a virtual switch are omitted.
+func teamSwitchNames(
+	teamNics []msftNetLbfoTeamNic,
+	switches []msvmVirtualEthernetSwitch,
+	externalPorts []msvmExternalEthernetPort,
+	saps []msvmEthernetDeviceSAPImplementation,
+	connections []msvmActiveConnection,
+) map[string]string {
+	switchNames := make(map[string]string, len(switches))
+	for _, vSwitch := range switches {
+		switchNames[vSwitch.Name] = vSwitch.ElementName
+	}
+
+	portDescriptions := make(map[string]string, len(externalPorts))
+	for _, port := range externalPorts {
+		portDescriptions[port.DeviceID] = port.ElementName
+	}
+
+	// endpointDescriptions maps the LAN endpoints of the external ports to the interface description of the adapter.
+	endpointDescriptions := make(map[string]string, len(externalPorts))
+
+	for _, sap := range saps {
+		if description, ok := portDescriptions[sap.Antecedent.DeviceID]; ok {
+			endpointDescriptions[sap.Dependent.Name] = description
+		}
+	}
+
+	// descriptionSwitches maps the interface description of the external adapters to the switch name.
+	descriptionSwitches := make(map[string]string, len(endpointDescriptions))
+
+	for _, connection := range connections {
+		for _, endpoints := range [][2]msvmLANEndpointReference{
+			{connection.Antecedent, connection.Dependent},
+			{connection.Dependent, connection.Antecedent},
+		} {
+			description, ok := endpointDescriptions[endpoints[0].Name]
+			if !ok {
+				continue
+			}
+
+			if switchName, ok := switchNames[endpoints[1].SystemName]; ok {
+				descriptionSwitches[description] = switchName
+			}
+		}
+	}
+
+	teamSwitches := make(map[string]string, len(teamNics))
+
+	for _, teamNic := range teamNics {
+		if switchName, ok := descriptionSwitches[teamNic.InterfaceDescription]; ok {
+			teamSwitches[teamNic.Team] = switchName
+		}
+	}
+
+	return teamSwitches
+}
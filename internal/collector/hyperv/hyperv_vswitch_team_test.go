@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamSwitchNames(t *testing.T) {
+	t.Parallel()
+
+	teamNics := []msftNetLbfoTeamNic{
+		{Team: "team01", InterfaceDescription: "Microsoft Network Adapter Multiplexor Driver"},
+		{Team: "team02", InterfaceDescription: "Microsoft Network Adapter Multiplexor Driver #2"},
+	}
+
+	switches := []msvmVirtualEthernetSwitch{
+		{Name: "5C2B1F1E-0E6A-4E3B-9B7E-0A4C2D6E8F10", ElementName: "external"},
+	}
+
+	externalPorts := []msvmExternalEthernetPort{
+		{DeviceID: "Microsoft:{A1}", ElementName: "Microsoft Network Adapter Multiplexor Driver"},
+		{DeviceID: "Microsoft:{A2}", ElementName: "Microsoft Network Adapter Multiplexor Driver #2"},
+	}
+
+	var sap1, sap2 msvmEthernetDeviceSAPImplementation
+
+	sap1.Antecedent.DeviceID = "Microsoft:{A1}"
+	sap1.Dependent = msvmLANEndpointReference{Name: "/DEVICE/{A1}", SystemName: "HOST01"}
+	sap2.Antecedent.DeviceID = "Microsoft:{A2}"
+	sap2.Dependent = msvmLANEndpointReference{Name: "/DEVICE/{A2}", SystemName: "HOST01"}
+
+	connections := []msvmActiveConnection{
+		{
+			Antecedent: msvmLANEndpointReference{Name: "/DEVICE/{A1}", SystemName: "HOST01"},
+			Dependent:  msvmLANEndpointReference{Name: "port01", SystemName: "5C2B1F1E-0E6A-4E3B-9B7E-0A4C2D6E8F10"},
+		},
+	}
+
+	// team02 is not connected to a virtual switch.
+	require.Equal(t,
+		map[string]string{"team01": "external"},
+		teamSwitchNames(teamNics, switches, externalPorts, []msvmEthernetDeviceSAPImplementation{sap1, sap2}, connections),
+	)
+
+	// The direction of the connection does not matter.
+	connections[0].Antecedent, connections[0].Dependent = connections[0].Dependent, connections[0].Antecedent
+
+	require.Equal(t,
+		map[string]string{"team01": "external"},
+		teamSwitchNames(teamNics, switches, externalPorts, []msvmEthernetDeviceSAPImplementation{sap1, sap2}, connections),
+	)
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// splitVMInstanceName splits a performance counter instance name in the format
+// <VM Name>:<device> into the VM name and the device part.
+// VM names may contain colons, so the instance name is split at the last colon.
+func splitVMInstanceName(name string) (string, string, bool) {
+	idx := strings.LastIndex(name, ":")
+	if idx <= 0 || idx == len(name)-1 {
+		return "", "", false
+	}
+
+	return name[:idx], name[idx+1:], true
+}
+
+// isLegacyEmulatedDevice reports whether the performance counter instance name belongs to
+// a legacy emulated device of a generation 1 VM, e.g. a virtual floppy disk or a legacy network adapter.
+// Floppy disks are only matched on the device part of the instance name, i.e. the part after the VM name
+// or the last element of a virtual disk path, so that VMs or folders named e.g. floppy-build are kept.
+func isLegacyEmulatedDevice(name string) bool {
+	name = strings.ToLower(name)
+
+	if strings.HasSuffix(name, ".vfd") || strings.Contains(name, "legacy network adapter") {
+		return true
+	}
+
+	device := name
+	if _, part, ok := splitVMInstanceName(name); ok {
+		device = part
+	}
+
+	device = device[strings.LastIndex(device, "-")+1:]
+
+	return strings.Contains(device, "floppy")
+}
+
+// excludeLegacyEmulatedDevices removes the instances of legacy emulated devices from data.
+func excludeLegacyEmulatedDevices[T any](data []T, name func(T) string) []T {
+	return slices.DeleteFunc(data, func(instance T) bool {
+		return isLegacyEmulatedDevice(name(instance))
+	})
+}
+
+// vmIDFromInstanceID returns the upper-case VM ID of a Hyper-V setting data InstanceID
+// in the format Microsoft:<VM ID>\<setting ID>.
+func vmIDFromInstanceID(instanceID string) (string, bool) {
+	id, ok := strings.CutPrefix(instanceID, "Microsoft:")
+	if !ok {
+		return "", false
+	}
+
+	id, _, ok = strings.Cut(id, `\`)
+	if !ok || id == "" {
+		return "", false
+	}
+
+	return strings.ToUpper(id), true
+}
+
+// vmNamesByID maps the upper-case VM IDs of the Msvm_ComputerSystem instances to the VM names.
+func vmNamesByID(vms []msvmComputerSystemID) map[string]string {
+	vmNames := make(map[string]string, len(vms))
+	for _, vm := range vms {
+		vmNames[strings.ToUpper(vm.Name)] = vm.ElementName
+	}
+
+	return vmNames
+}
+
+// vmNameFromInstanceID returns the name of the VM a Hyper-V setting data InstanceID belongs to.
+// Settings of snapshots carry the snapshot ID instead of a VM ID and are not resolved.
+func vmNameFromInstanceID(vmNames map[string]string, instanceID string) (string, bool) {
+	vmID, ok := vmIDFromInstanceID(instanceID)
+	if !ok {
+		return "", false
+	}
+
+	vmName, ok := vmNames[vmID]
+
+	return vmName, ok
+}
+
+// vmNetworkAdapterKeyFromInstanceID returns the key <VM ID>--<adapter ID> of a network adapter
+// setting data InstanceID in the format Microsoft:<VM ID>\<adapter ID>[\<suffix>].
+func vmNetworkAdapterKeyFromInstanceID(instanceID string) (string, bool) {
+	vmID, ok := vmIDFromInstanceID(instanceID)
+	if !ok {
+		return "", false
+	}
+
+	_, adapterID, _ := strings.Cut(instanceID, `\`)
+	adapterID, _, _ = strings.Cut(adapterID, `\`)
+
+	if adapterID == "" {
+		return "", false
+	}
+
+	return vmID + "--" + strings.ToUpper(adapterID), true
+}
+
+// vmNetworkAdapterKeyFromPerfInstance returns the key <VM ID>--<adapter ID> of a Hyper-V Virtual Network Adapter
+// performance counter instance name in the format <VM name>_<adapter name>_<VM ID>--<adapter ID>.
+func vmNetworkAdapterKeyFromPerfInstance(name string) (string, bool) {
+	idx := strings.LastIndex(name, "_")
+	if idx == -1 {
+		return "", false
+	}
+
+	vmID, adapterID, ok := strings.Cut(name[idx+1:], "--")
+	if !ok || vmID == "" || adapterID == "" {
+		return "", false
+	}
+
+	return strings.ToUpper(vmID) + "--" + strings.ToUpper(adapterID), true
+}
+
+// formatMACAddress formats a MAC address reported by WMI without separators, e.g. 00155D012345,
+// as colon-separated address. Other formats are returned unchanged.
+func formatMACAddress(address string) string {
+	if len(address) != 12 {
+		return address
+	}
+
+	var sb strings.Builder
+
+	for i := 0; i < len(address); i += 2 {
+		if i > 0 {
+			sb.WriteByte(':')
+		}
+
+		sb.WriteString(strings.ToUpper(address[i : i+2]))
+	}
+
+	return sb.String()
+}
+
+// driverFilePath converts the PathName of a Win32_SystemDriver into a Win32 path.
+// Driver paths may be NT paths, e.g. \??\C:\Windows\system32\drivers\vmbus.sys or \SystemRoot\System32\drivers\vmbus.sys.
+func driverFilePath(pathName string) string {
+	if path, ok := strings.CutPrefix(pathName, `\??\`); ok {
+		return path
+	}
+
+	if path, ok := cutPrefixFold(pathName, `\SystemRoot\`); ok {
+		return filepath.Join(os.Getenv("SystemRoot"), path)
+	}
+
+	return pathName
+}
+
+// cutPrefixFold is strings.CutPrefix with case-insensitive matching.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+
+	return s[len(prefix):], true
+}
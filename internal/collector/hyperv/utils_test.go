@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExcludeLegacyEmulatedDevices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("virtual storage device", func(t *testing.T) {
+		t.Parallel()
+
+		data := []perfDataCounterValuesVirtualStorageDevice{
+			{Name: `D:-VMs-vm01-disk.vhdx`},
+			{Name: `D:-VMs-vm01-boot.VFD`},
+			{Name: `D:-VMs-vm02-Virtual Floppy Disk`},
+			{Name: `D:-VMs-vm02-disk.avhdx`},
+			{Name: `D:-VMs-floppy-build-disk.vhdx`},
+		}
+
+		data = excludeLegacyEmulatedDevices(data, func(data perfDataCounterValuesVirtualStorageDevice) string {
+			return data.Name
+		})
+
+		require.Equal(t, []perfDataCounterValuesVirtualStorageDevice{
+			{Name: `D:-VMs-vm01-disk.vhdx`},
+			{Name: `D:-VMs-vm02-disk.avhdx`},
+			{Name: `D:-VMs-floppy-build-disk.vhdx`},
+		}, data)
+	})
+
+	t.Run("virtual network adapter", func(t *testing.T) {
+		t.Parallel()
+
+		data := []perfDataCounterValuesVirtualNetworkAdapter{
+			{Name: `vm01_Network Adapter_F5B9F3C5-8E43-4D2B-9E0E-2D6C8F1E7A11--2A8B1C3D-4E5F-6A7B-8C9D-0E1F2A3B4C5D`},
+			{Name: `vm01_Legacy Network Adapter_0C3D7A3E-5B91-4F8A-A0C2-9E4B1D6F2A77--1B2C3D4E-5F6A-7B8C-9D0E-1F2A3B4C5D6E`},
+			{Name: `floppy-build_Network Adapter_B637F346-6A6E-4DEC-AF52-BD70CB80A21D--4764334D-E001-4176-82EE-5594EC9B530E`},
+		}
+
+		data = excludeLegacyEmulatedDevices(data, func(data perfDataCounterValuesVirtualNetworkAdapter) string {
+			return data.Name
+		})
+
+		require.Equal(t, []perfDataCounterValuesVirtualNetworkAdapter{
+			{Name: `vm01_Network Adapter_F5B9F3C5-8E43-4D2B-9E0E-2D6C8F1E7A11--2A8B1C3D-4E5F-6A7B-8C9D-0E1F2A3B4C5D`},
+			{Name: `floppy-build_Network Adapter_B637F346-6A6E-4DEC-AF52-BD70CB80A21D--4764334D-E001-4176-82EE-5594EC9B530E`},
+		}, data)
+	})
+}
+
+func TestIsLegacyEmulatedDevice(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		expected bool
+	}{
+		{name: `vm01:Floppy Disk`, expected: true},
+		{name: `floppy-build:Network Adapter`, expected: false},
+		{name: `floppy-build:Floppy Disk`, expected: true},
+		{name: `D:-VMs-floppy-build-disk.vhdx`, expected: false},
+		{name: `D:-VMs-floppy-build-boot.vfd`, expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, isLegacyEmulatedDevice(tc.name))
+		})
+	}
+}
+
+func TestVMIDFromInstanceID(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		instanceID string
+		vmID       string
+		ok         bool
+	}{
+		{
+			instanceID: `Microsoft:b637f346-6a6e-4dec-af52-bd70cb80a21d\b637f346-6a6e-4dec-af52-bd70cb80a21d\0`,
+			vmID:       "B637F346-6A6E-4DEC-AF52-BD70CB80A21D",
+			ok:         true,
+		},
+		{
+			instanceID: `Microsoft:B637F346-6A6E-4DEC-AF52-BD70CB80A21D\4764334d-e001-4176-82ee-5594ec9b530e`,
+			vmID:       "B637F346-6A6E-4DEC-AF52-BD70CB80A21D",
+			ok:         true,
+		},
+		{
+			instanceID: `Microsoft:B637F346-6A6E-4DEC-AF52-BD70CB80A21D`,
+		},
+		{
+			instanceID: `B637F346-6A6E-4DEC-AF52-BD70CB80A21D\0`,
+		},
+	} {
+		t.Run(tc.instanceID, func(t *testing.T) {
+			t.Parallel()
+
+			vmID, ok := vmIDFromInstanceID(tc.instanceID)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.vmID, vmID)
+		})
+	}
+}
+
+func TestVMNameFromInstanceID(t *testing.T) {
+	t.Parallel()
+
+	vmNames := vmNamesByID([]msvmComputerSystemID{
+		{Name: "b637f346-6a6e-4dec-af52-bd70cb80a21d", ElementName: "vm01"},
+		{Name: "F5B9F3C5-8E43-4D2B-9E0E-2D6C8F1E7A11", ElementName: "vm02"},
+	})
+
+	for _, tc := range []struct {
+		instanceID string
+		vmName     string
+		ok         bool
+	}{
+		{
+			instanceID: `Microsoft:B637F346-6A6E-4DEC-AF52-BD70CB80A21D\b637f346-6a6e-4dec-af52-bd70cb80a21d\0`,
+			vmName:     "vm01",
+			ok:         true,
+		},
+		{
+			instanceID: `Microsoft:f5b9f3c5-8e43-4d2b-9e0e-2d6c8f1e7a11\4764334d-e001-4176-82ee-5594ec9b530e`,
+			vmName:     "vm02",
+			ok:         true,
+		},
+		{
+			// snapshot settings carry the snapshot ID
+			instanceID: `Microsoft:0C3D7A3E-5B91-4F8A-A0C2-9E4B1D6F2A77\4764334d-e001-4176-82ee-5594ec9b530e`,
+		},
+		{
+			instanceID: `B637F346-6A6E-4DEC-AF52-BD70CB80A21D\0`,
+		},
+	} {
+		t.Run(tc.instanceID, func(t *testing.T) {
+			t.Parallel()
+
+			vmName, ok := vmNameFromInstanceID(vmNames, tc.instanceID)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.vmName, vmName)
+		})
+	}
+}
+
+func TestVMNetworkAdapterKey(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		fn   func(string) (string, bool)
+		in   string
+		key  string
+		ok   bool
+	}{
+		{
+			name: "port setting data",
+			fn:   vmNetworkAdapterKeyFromInstanceID,
+			in:   `Microsoft:b637f346-6a6e-4dec-af52-bd70cb80a21d\2a8b1c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d`,
+			key:  "B637F346-6A6E-4DEC-AF52-BD70CB80A21D--2A8B1C3D-4E5F-6A7B-8C9D-0E1F2A3B4C5D",
+			ok:   true,
+		},
+		{
+			name: "port allocation setting data",
+			fn:   vmNetworkAdapterKeyFromInstanceID,
+			in:   `Microsoft:B637F346-6A6E-4DEC-AF52-BD70CB80A21D\2A8B1C3D-4E5F-6A7B-8C9D-0E1F2A3B4C5D\C`,
+			key:  "B637F346-6A6E-4DEC-AF52-BD70CB80A21D--2A8B1C3D-4E5F-6A7B-8C9D-0E1F2A3B4C5D",
+			ok:   true,
+		},
+		{
+			name: "perf instance",
+			fn:   vmNetworkAdapterKeyFromPerfInstance,
+			in:   `vm_01_Network Adapter_B637F346-6A6E-4DEC-AF52-BD70CB80A21D--2A8B1C3D-4E5F-6A7B-8C9D-0E1F2A3B4C5D`,
+			key:  "B637F346-6A6E-4DEC-AF52-BD70CB80A21D--2A8B1C3D-4E5F-6A7B-8C9D-0E1F2A3B4C5D",
+			ok:   true,
+		},
+		{
+			name: "perf instance without IDs",
+			fn:   vmNetworkAdapterKeyFromPerfInstance,
+			in:   `Default Switch_Network Adapter`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, ok := tc.fn(tc.in)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.key, key)
+		})
+	}
+}
+
+func TestFormatMACAddress(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "00:15:5D:01:23:AB", formatMACAddress("00155d0123ab"))
+	require.Equal(t, "00-15-5D-01-23-AB", formatMACAddress("00-15-5D-01-23-AB"))
+	require.Empty(t, formatMACAddress(""))
+}
+
+func TestDriverFilePath(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `C:\Windows\system32\drivers\vmbus.sys`, driverFilePath(`\??\C:\Windows\system32\drivers\vmbus.sys`))
+	require.Equal(t, `C:\Windows\system32\drivers\vmbus.sys`, driverFilePath(`C:\Windows\system32\drivers\vmbus.sys`))
+	require.Equal(t, filepath.Join(os.Getenv("SystemRoot"), `System32\drivers\storflt.sys`), driverFilePath(`\SystemRoot\System32\drivers\storflt.sys`))
+	require.Equal(t, filepath.Join(os.Getenv("SystemRoot"), `system32\drivers\storflt.sys`), driverFilePath(`\systemroot\system32\drivers\storflt.sys`))
+}
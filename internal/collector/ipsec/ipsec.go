@@ -0,0 +1,356 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package ipsec
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "ipsec"
+
+type Config struct{}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{}
+
+// keyingModuleObjects are the performance objects of the IPsec keying modules.
+// The main mode and quick mode metrics are the sum over all objects.
+//
+//nolint:gochecknoglobals
+var keyingModuleObjects = []string{
+	"IPsec AuthIP IPv4",
+	"IPsec AuthIP IPv6",
+	"IPsec IKEv1 IPv4",
+	"IPsec IKEv1 IPv6",
+	"IPsec IKEv2 IPv4",
+	"IPsec IKEv2 IPv6",
+}
+
+// A Collector is a Prometheus Collector for IPsec metrics.
+type Collector struct {
+	config    Config
+	miSession *mi.Session
+	miQuery   mi.Query
+
+	perfDataCollectorDriver        *pdh.Collector
+	perfDataObjectDriver           []perfDataCounterValuesDriver
+	perfDataCollectorKeyingModules []*pdh.Collector
+	perfDataObjectKeyingModule     []perfDataCounterValuesKeyingModule
+
+	securityAssociations        *prometheus.Desc
+	pendingSecurityAssociations *prometheus.Desc
+	bytesReceivedTotal          *prometheus.Desc
+	bytesSentTotal              *prometheus.Desc
+	inboundPacketsDroppedTotal  *prometheus.Desc
+
+	mainModeSecurityAssociations     *prometheus.Desc
+	quickModeSecurityAssociations    *prometheus.Desc
+	mainModeNegotiationsFailedTotal  *prometheus.Desc
+	quickModeNegotiationsFailedTotal *prometheus.Desc
+
+	connectionSecurityRuleEnabled *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(_ *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) Close() error {
+	c.perfDataCollectorDriver.Close()
+
+	for _, collector := range c.perfDataCollectorKeyingModules {
+		collector.Close()
+	}
+
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	miQuery, err := mi.Select("InstanceID", "ElementName", "Enabled").From("MSFT_NetConSecRule").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQuery = miQuery
+	c.miSession = miSession
+
+	c.securityAssociations = bdf.NewDesc(
+		Name,
+		"security_associations",
+		"Number of active IPsec security associations of the IPsec driver",
+		nil,
+	)
+	c.pendingSecurityAssociations = bdf.NewDesc(
+		Name,
+		"pending_security_associations",
+		"Number of IPsec security associations of the IPsec driver which are not yet established",
+		nil,
+	)
+	c.bytesReceivedTotal = bdf.NewDesc(
+		Name,
+		"bytes_received_total",
+		"Total bytes received over IPsec protected connections",
+		[]string{"mode"},
+	)
+	c.bytesSentTotal = bdf.NewDesc(
+		Name,
+		"bytes_sent_total",
+		"Total bytes sent over IPsec protected connections",
+		[]string{"mode"},
+	)
+	c.inboundPacketsDroppedTotal = bdf.NewDesc(
+		Name,
+		"inbound_packets_dropped_total",
+		"Total inbound packets dropped by the IPsec driver",
+		nil,
+	)
+	c.mainModeSecurityAssociations = bdf.NewDesc(
+		Name,
+		"main_mode_security_associations",
+		"Number of active main mode security associations of all keying modules",
+		nil,
+	)
+	c.quickModeSecurityAssociations = bdf.NewDesc(
+		Name,
+		"quick_mode_security_associations",
+		"Number of active quick mode security associations of all keying modules",
+		nil,
+	)
+	c.mainModeNegotiationsFailedTotal = bdf.NewDesc(
+		Name,
+		"main_mode_negotiations_failed_total",
+		"Total failed main mode negotiations of all keying modules",
+		nil,
+	)
+	c.quickModeNegotiationsFailedTotal = bdf.NewDesc(
+		Name,
+		"quick_mode_negotiations_failed_total",
+		"Total failed quick mode negotiations of all keying modules",
+		nil,
+	)
+	c.connectionSecurityRuleEnabled = bdf.NewDesc(
+		Name,
+		"connection_security_rule_enabled",
+		"1 if the connection security rule is enabled, 0 otherwise",
+		[]string{"rule", "display_name"},
+	)
+
+	logger = logger.With(slog.String("collector", Name))
+	errs := make([]error, 0)
+
+	c.perfDataCollectorDriver, err = pdh.NewCollector[perfDataCounterValuesDriver](logger, pdh.CounterTypeRaw, "IPsec Driver", nil, pdh.WithCollectorName(Name))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to create IPsec Driver collector: %w", err))
+	}
+
+	c.perfDataCollectorKeyingModules = make([]*pdh.Collector, 0, len(keyingModuleObjects))
+
+	for _, object := range keyingModuleObjects {
+		collector, err := pdh.NewCollector[perfDataCounterValuesKeyingModule](logger, pdh.CounterTypeRaw, object, nil, pdh.WithCollectorName(Name))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create %s collector: %w", object, err))
+		}
+
+		c.perfDataCollectorKeyingModules = append(c.perfDataCollectorKeyingModules, collector)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
+	errs := make([]error, 0)
+
+	if err := c.collectDriver(ch); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := c.collectKeyingModules(ch); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := c.collectConnectionSecurityRules(ch); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectDriver(ch chan<- prometheus.Metric) error {
+	if err := c.perfDataCollectorDriver.Collect(&c.perfDataObjectDriver); err != nil {
+		return fmt.Errorf("failed to collect IPsec Driver metrics: %w", err)
+	} else if len(c.perfDataObjectDriver) == 0 {
+		return fmt.Errorf("failed to collect IPsec Driver metrics: %w", types.ErrNoDataUnexpected)
+	}
+
+	data := c.perfDataObjectDriver[0]
+
+	ch <- prometheus.MustNewConstMetric(
+		c.securityAssociations,
+		prometheus.GaugeValue,
+		data.ActiveSecurityAssociations,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.pendingSecurityAssociations,
+		prometheus.GaugeValue,
+		data.PendingSecurityAssociations,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bytesReceivedTotal,
+		prometheus.CounterValue,
+		data.BytesReceivedInTransportModePerSec,
+		"transport",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bytesReceivedTotal,
+		prometheus.CounterValue,
+		data.BytesReceivedInTunnelModePerSec,
+		"tunnel",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bytesSentTotal,
+		prometheus.CounterValue,
+		data.BytesSentInTransportModePerSec,
+		"transport",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bytesSentTotal,
+		prometheus.CounterValue,
+		data.BytesSentInTunnelModePerSec,
+		"tunnel",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.inboundPacketsDroppedTotal,
+		prometheus.CounterValue,
+		data.TotalInboundPacketsDropped,
+	)
+
+	return nil
+}
+
+// collectKeyingModules sums the counters of all keying modules. If any keying module fails,
+// no metrics are sent, since the totals would be incomplete.
+func (c *Collector) collectKeyingModules(ch chan<- prometheus.Metric) error {
+	var total perfDataCounterValuesKeyingModule
+
+	for i, collector := range c.perfDataCollectorKeyingModules {
+		if err := collector.Collect(&c.perfDataObjectKeyingModule); err != nil {
+			return fmt.Errorf("failed to collect %s metrics: %w", keyingModuleObjects[i], err)
+		} else if len(c.perfDataObjectKeyingModule) == 0 {
+			return fmt.Errorf("failed to collect %s metrics: %w", keyingModuleObjects[i], types.ErrNoDataUnexpected)
+		}
+
+		data := c.perfDataObjectKeyingModule[0]
+
+		total.ActiveMainModeSAs += data.ActiveMainModeSAs
+		total.ActiveQuickModeSAs += data.ActiveQuickModeSAs
+		total.FailedMainModeNegotiations += data.FailedMainModeNegotiations
+		total.FailedQuickModeNegotiations += data.FailedQuickModeNegotiations
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.mainModeSecurityAssociations,
+		prometheus.GaugeValue,
+		total.ActiveMainModeSAs,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.quickModeSecurityAssociations,
+		prometheus.GaugeValue,
+		total.ActiveQuickModeSAs,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.mainModeNegotiationsFailedTotal,
+		prometheus.CounterValue,
+		total.FailedMainModeNegotiations,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.quickModeNegotiationsFailedTotal,
+		prometheus.CounterValue,
+		total.FailedQuickModeNegotiations,
+	)
+
+	return nil
+}
+
+func (c *Collector) collectConnectionSecurityRules(ch chan<- prometheus.Metric) error {
+	var dst []msftNetConSecRule
+	if err := c.miSession.Query(&dst, mi.NamespaceRootStandardCimv2, c.miQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	for _, rule := range dst {
+		// Enabled is 1 for true and 2 for false.
+		enabled := 0.0
+		if rule.Enabled == 1 {
+			enabled = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionSecurityRuleEnabled,
+			prometheus.GaugeValue,
+			enabled,
+			rule.InstanceID,
+			rule.ElementName,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package ipsec
+
+// perfDataCounterValuesDriver represents the IPsec Driver performance object.
+type perfDataCounterValuesDriver struct {
+	ActiveSecurityAssociations         float64 `perfdata:"Active Security Associations"`
+	PendingSecurityAssociations        float64 `perfdata:"Pending Security Associations"`
+	BytesReceivedInTransportModePerSec float64 `perfdata:"Bytes Received in Transport Mode/sec"`
+	BytesSentInTransportModePerSec     float64 `perfdata:"Bytes Sent in Transport Mode/sec"`
+	BytesReceivedInTunnelModePerSec    float64 `perfdata:"Bytes Received in Tunnel Mode/sec"`
+	BytesSentInTunnelModePerSec        float64 `perfdata:"Bytes Sent in Tunnel Mode/sec"`
+	TotalInboundPacketsDropped         float64 `perfdata:"Total Inbound Packets Dropped"`
+}
+
+// perfDataCounterValuesKeyingModule represents the IPsec IKEv1, AuthIP and IKEv2 performance objects.
+// Each keying module publishes a separate object per address family.
+type perfDataCounterValuesKeyingModule struct {
+	ActiveMainModeSAs           float64 `perfdata:"Active Main Mode SAs"`
+	ActiveQuickModeSAs          float64 `perfdata:"Active Quick Mode SAs"`
+	FailedMainModeNegotiations  float64 `perfdata:"Failed Main Mode Negotiations"`
+	FailedQuickModeNegotiations float64 `perfdata:"Failed Quick Mode Negotiations"`
+}
+
+// msftNetConSecRule represents the MSFT_NetConSecRule WMI class.
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/wfascimprov/msft-netconsecrule
+type msftNetConSecRule struct {
+	InstanceID  string `mi:"InstanceID"`
+	ElementName string `mi:"ElementName"`
+	Enabled     uint16 `mi:"Enabled"`
+}
@@ -51,6 +51,7 @@ type Config struct {
 	CollectorsEnabled []string       `yaml:"enabled"`
 	VolumeInclude     *regexp.Regexp `yaml:"volume-include"`
 	VolumeExclude     *regexp.Regexp `yaml:"volume-exclude"`
+	InstancePattern   string         `yaml:"instance-pattern"`
 }
 
 //nolint:gochecknoglobals
@@ -58,8 +59,9 @@ var ConfigDefaults = Config{
 	CollectorsEnabled: []string{
 		subCollectorMetrics,
 	},
-	VolumeInclude: types.RegExpAny,
-	VolumeExclude: types.RegExpEmpty,
+	VolumeInclude:   types.RegExpAny,
+	VolumeExclude:   types.RegExpEmpty,
+	InstancePattern: "",
 }
 
 // A Collector is a Prometheus Collector for perflib logicalDisk metrics.
@@ -147,6 +149,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Regexp of volumes to include. Volume name must both match include and not match exclude to be included.",
 	).Default(".+").StringVar(&volumeInclude)
 
+	app.Flag(
+		"collector.logical_disk.instance-pattern",
+		"PDH wildcard pattern of the LogicalDisk instances to collect, e.g. \"C:\" or \"HarddiskVolume*\". Unlike volume-include, the instances are filtered by PDH. Defaults to all instances.",
+	).Default(ConfigDefaults.InstancePattern).StringVar(&c.config.InstancePattern)
+
 	app.Flag(
 		"collector.logical_disk.enabled",
 		fmt.Sprintf("Comma-separated list of collectors to use. Available collectors: %s, %s. Defaults to metrics, if not specified.",
@@ -188,9 +195,8 @@ func (c *Collector) Close() error {
 	return nil
 }
 
-func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.logger = logger.With(slog.String("collector", Name))
-
+// Validate checks the configuration of the collector without accessing PDH or WMI.
+func (c *Collector) Validate() error {
 	for _, collector := range c.config.CollectorsEnabled {
 		if !slices.Contains([]string{subCollectorMetrics, subCollectorBitlocker}, collector) {
 			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
@@ -199,6 +205,16 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		}
 	}
 
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
 	c.information = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"A metric with a constant '1' value labeled with logical disk information",
@@ -330,9 +346,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
-	var err error
+	instances, err := pdh.InstancesFromPattern(c.config.InstancePattern)
+	if err != nil {
+		return fmt.Errorf("collector.logical_disk.instance-pattern: %w", err)
+	}
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "LogicalDisk", pdh.InstancesAll)
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "LogicalDisk", instances, pdh.WithCollectorName(Name))
 	if err != nil {
 		return fmt.Errorf("failed to create LogicalDisk collector: %w", err)
 	}
@@ -70,10 +70,8 @@ type msClusterNode struct {
 }
 
 func (c *Collector) buildNode() error {
-	buildNumber := osversion.Build()
-
 	wmiSelect := "BuildNumber,Characteristics,DynamicWeight,Flags,MajorVersion,MinorVersion,Name,NeedsPreventQuorum,NodeDrainStatus,NodeHighestVersion,NodeLowestVersion,NodeWeight,State,StatusInformation"
-	if buildNumber >= osversion.LTSC2022 {
+	if osversion.AtLeast(osversion.LTSC2022) {
 		wmiSelect += ",DetectedCloudPlatform"
 	}
 
@@ -18,37 +18,160 @@
 package os
 
 import (
+	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"log/slog"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
+	"github.com/prometheus-community/windows_exporter/internal/headers/netapi32"
+	"github.com/prometheus-community/windows_exporter/internal/headers/powrprof"
+	"github.com/prometheus-community/windows_exporter/internal/headers/psapi"
 	"github.com/prometheus-community/windows_exporter/internal/headers/sysinfoapi"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
 )
 
 const Name = "os"
 
-type Config struct{}
+const (
+	// hostnameResolutionAttempts is the number of GetComputerName calls per computer name format and scrape.
+	hostnameResolutionAttempts = 3
+
+	// hostnameResolutionBackoff is the delay between GetComputerName calls.
+	hostnameResolutionBackoff = 100 * time.Millisecond
+)
+
+type Config struct {
+	HandleCountWarningThreshold  uint64         `yaml:"handle-count-warning-threshold"`
+	PolicyValues                 []string       `yaml:"policy-values"`
+	SecureChannelCheckInterval   time.Duration  `yaml:"secure-channel-check-interval"`
+	InstalledApplicationsInclude *regexp.Regexp `yaml:"installed-applications-include"`
+	WERReportQueuePath           string         `yaml:"wer-report-queue-path"`
+	WERReportArchivePath         string         `yaml:"wer-report-archive-path"`
+	MinidumpPath                 string         `yaml:"minidump-path"`
+}
 
 //nolint:gochecknoglobals
-var ConfigDefaults = Config{}
+var ConfigDefaults = Config{
+	HandleCountWarningThreshold:  0,
+	PolicyValues:                 []string{},
+	SecureChannelCheckInterval:   0,
+	InstalledApplicationsInclude: types.RegExpEmpty,
+	WERReportQueuePath:           "",
+	WERReportArchivePath:         "",
+	MinidumpPath:                 "",
+}
+
+// registryKey is the subset of registry.Key used by the collector. It allows to replace the registry in tests.
+type registryKey interface {
+	GetStringValue(name string) (string, uint32, error)
+	GetIntegerValue(name string) (uint64, uint32, error)
+	Close() error
+}
 
 // A Collector is a Prometheus Collector for WMI metrics.
 type Collector struct {
-	config Config
+	config    Config
+	logger    *slog.Logger
+	miSession *mi.Session
+
+	// openCurrentVersionKey opens the Windows NT CurrentVersion registry key.
+	openCurrentVersionKey func() (registryKey, error)
+	// openPolicyKey opens a registry key of a policy value.
+	openPolicyKey func(root registry.Key, path string) (registryKey, error)
+
+	// joinedDomain returns the domain the computer is joined to.
+	joinedDomain func() (string, error)
+	// verifySecureChannel verifies the secure channel to the domain.
+	verifySecureChannel func(domain string) (netapi32.SecureChannelStatus, error)
+	// readInstalledApplications reads the installed applications from the Uninstall registry keys.
+	readInstalledApplications func() ([]installedApplication, error)
+	// getComputerName returns the computer name in the given format.
+	getComputerName func(format sysinfoapi.WinComputerNameFormat) (string, error)
+
+	// hostnameResolutionErrors counts the failed GetComputerName calls, including failures which succeeded on retry.
+	hostnameResolutionErrors atomic.Uint64
+
+	policyValues []policyValue
+
+	// secureChannelMu guards secureChannel, which is updated by the secure channel check in the background.
+	secureChannelMu  sync.Mutex
+	secureChannel    *secureChannelStatus
+	ctxCancelFn      context.CancelFunc
+	backgroundWorker sync.WaitGroup
+
+	// installedApplicationsMu guards the cached installed applications, since concurrent scrapes may refresh them.
+	installedApplicationsMu          sync.Mutex
+	installedApplicationsCountValue  float64
+	installedApplicationsMatched     []installedApplication
+	installedApplicationsLastRefresh time.Time
+
+	// crashDumpsMu guards the cached crash report and dump directory listing.
+	crashDumpsMu          sync.Mutex
+	crashDumpsCache       crashDumps
+	crashDumpsLastRefresh time.Time
+
+	// inventoryMu guards the cached results of the inventory WMI queries.
+	inventoryMu          sync.Mutex
+	inventoryCache       inventory
+	inventoryLastRefresh time.Time
+
+	defenderMIQuery   mi.Query
+	activationMIQuery mi.Query
+	diskDriveMIQuery  mi.Query
+	volumeMIQuery     mi.Query
+
+	// wmiQueryDuration observes the duration of the WMI queries run during Collect, labelled by the queried WMI class.
+	wmiQueryDuration *prometheus.HistogramVec
+
+	// defenderEnabled is false, if Windows Defender is not installed or its WMI provider is not available.
+	defenderEnabled bool
 
 	installTimeTimestamp float64
+	firmwareTypeValue    float64
+
+	hostname          *prometheus.Desc
+	osInformation     *prometheus.Desc
+	installTime       *prometheus.Desc
+	totalHandleCount  *prometheus.Desc
+	commitCharge      *prometheus.Desc
+	commitLimit       *prometheus.Desc
+	activationStatus  *prometheus.Desc
+	physicalDiskInfo  *prometheus.Desc
+	physicalDiskCount *prometheus.Desc
+	volumeCount       *prometheus.Desc
 
-	hostname      *prometheus.Desc
-	osInformation *prometheus.Desc
-	installTime   *prometheus.Desc
+	powerPlanInfo                  *prometheus.Desc
+	powerPlanProcessorMinimumState *prometheus.Desc
+	powerPlanProcessorMaximumState *prometheus.Desc
+
+	defenderEngineVersion *prometheus.Desc
+
+	automaticMaintenanceLastRun   *prometheus.Desc
+	firmwareType                  *prometheus.Desc
+	policy                        *prometheus.Desc
+	secureChannelHealthy          *prometheus.Desc
+	secureChannelInfo             *prometheus.Desc
+	installedApplicationsCount    *prometheus.Desc
+	installedApplicationInfo      *prometheus.Desc
+	hostnameResolutionErrorsTotal *prometheus.Desc
+	werReports                    *prometheus.Desc
+	kernelDumpLastTimestamp       *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -56,15 +179,91 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.InstalledApplicationsInclude == nil {
+		config.InstalledApplicationsInclude = ConfigDefaults.InstalledApplicationsInclude
+	}
+
 	c := &Collector{
-		config: *config,
+		config:                    *config,
+		openCurrentVersionKey:     openCurrentVersionKey,
+		openPolicyKey:             openPolicyKey,
+		joinedDomain:              joinedDomain,
+		verifySecureChannel:       netapi32.VerifySecureChannel,
+		readInstalledApplications: readInstalledApplications,
+		getComputerName:           sysinfoapi.GetComputerName,
 	}
 
 	return c
 }
 
-func NewWithFlags(_ *kingpin.Application) *Collector {
-	return &Collector{}
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config:                    ConfigDefaults,
+		openCurrentVersionKey:     openCurrentVersionKey,
+		openPolicyKey:             openPolicyKey,
+		joinedDomain:              joinedDomain,
+		verifySecureChannel:       netapi32.VerifySecureChannel,
+		readInstalledApplications: readInstalledApplications,
+		getComputerName:           sysinfoapi.GetComputerName,
+	}
+
+	var policyValues, installedApplicationsInclude string
+
+	app.Flag(
+		"collector.os.handle-count-warning-threshold",
+		"Log a warning if the total number of open handles exceeds this value. 0 disables the warning.",
+	).Default(strconv.FormatUint(ConfigDefaults.HandleCountWarningThreshold, 10)).Uint64Var(&c.config.HandleCountWarningThreshold)
+
+	app.Flag(
+		"collector.os.policy-values",
+		`Comma-separated list of registry policy values to expose as windows_os_policy metric, e.g. HKLM\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU\NoAutoUpdate.`,
+	).Default(strings.Join(ConfigDefaults.PolicyValues, ",")).StringVar(&policyValues)
+
+	app.Flag(
+		"collector.os.secure-channel-check-interval",
+		"Interval in which the secure channel of the computer account to its domain is verified. Each verification contacts a domain controller. 0 disables the check.",
+	).Default(ConfigDefaults.SecureChannelCheckInterval.String()).DurationVar(&c.config.SecureChannelCheckInterval)
+
+	app.Flag(
+		"collector.os.installed-applications.include",
+		"Regexp of the display names of installed applications to expose as windows_os_installed_application_info metric. By default, no application is exposed.",
+	).Default("").StringVar(&installedApplicationsInclude)
+
+	app.Flag(
+		"collector.os.wer-report-queue-path",
+		`Directory of queued Windows Error Reporting reports. Defaults to %ProgramData%\Microsoft\Windows\WER\ReportQueue.`,
+	).Default(ConfigDefaults.WERReportQueuePath).StringVar(&c.config.WERReportQueuePath)
+
+	app.Flag(
+		"collector.os.wer-report-archive-path",
+		`Directory of archived Windows Error Reporting reports. Defaults to %ProgramData%\Microsoft\Windows\WER\ReportArchive.`,
+	).Default(ConfigDefaults.WERReportArchivePath).StringVar(&c.config.WERReportArchivePath)
+
+	app.Flag(
+		"collector.os.minidump-path",
+		"Directory of kernel minidumps. Defaults to the MinidumpDir value of the CrashControl registry key.",
+	).Default(ConfigDefaults.MinidumpPath).StringVar(&c.config.MinidumpPath)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		var err error
+
+		c.config.InstalledApplicationsInclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", installedApplicationsInclude))
+		if err != nil {
+			return fmt.Errorf("collector.os.installed-applications.include: %w", err)
+		}
+
+		c.config.PolicyValues = make([]string, 0)
+
+		for policyValue := range strings.SplitSeq(policyValues, ",") {
+			if policyValue = strings.TrimSpace(policyValue); policyValue != "" {
+				c.config.PolicyValues = append(c.config.PolicyValues, policyValue)
+			}
+		}
+
+		return nil
+	})
+
+	return c
 }
 
 func (c *Collector) GetName() string {
@@ -72,10 +271,92 @@ func (c *Collector) GetName() string {
 }
 
 func (c *Collector) Close() error {
+	if c.ctxCancelFn != nil {
+		c.ctxCancelFn()
+	}
+
+	c.backgroundWorker.Wait()
+
+	return nil
+}
+
+// Validate checks the configured policy values without accessing the registry.
+func (c *Collector) Validate() error {
+	for _, entry := range c.config.PolicyValues {
+		if _, err := parsePolicyValue(entry); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	c.policyValues = make([]policyValue, 0, len(c.config.PolicyValues))
+
+	for _, entry := range c.config.PolicyValues {
+		value, err := parsePolicyValue(entry)
+		if err != nil {
+			return err
+		}
+
+		c.policyValues = append(c.policyValues, value)
+	}
+
+	defenderMIQuery, err := mi.Select("AMEngineVersion").From("MSFT_MpComputerStatus").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	// 55c92734-d682-4d71-983e-d6ec3f16059f is the application ID of Windows itself.
+	// Products without a partial product key are not installed.
+	activationMIQuery, err := mi.Select("LicenseStatus").From("SoftwareLicensingProduct").
+		WhereEqual("ApplicationID", "55c92734-d682-4d71-983e-d6ec3f16059f").
+		WhereNotNull("PartialProductKey").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	diskDriveMIQuery, err := mi.Select("Model", "SerialNumber", "FirmwareRevision").From("Win32_DiskDrive").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	volumeMIQuery, err := mi.Select("DeviceID").From("Win32_Volume").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.diskDriveMIQuery = diskDriveMIQuery
+	c.volumeMIQuery = volumeMIQuery
+	c.activationMIQuery = activationMIQuery
+	c.defenderMIQuery = defenderMIQuery
+	c.miSession = miSession
+
+	c.wmiQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: types.Namespace,
+		Subsystem: Name,
+		Name:      "wmi_query_duration_seconds",
+		Help:      "Duration of the WMI queries of the os collector, labelled by the queried WMI class",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5},
+	}, []string{"query"})
+
+	var defenderStatus []msftMpComputerStatus
+	if err := c.miSession.Query(&defenderStatus, mi.NamespaceRootWindowsDefender, c.defenderMIQuery); err != nil {
+		c.logger.Debug("Windows Defender status is not available, skipping defender metrics",
+			slog.Any("err", err),
+		)
+	} else {
+		c.defenderEnabled = true
+	}
+
 	productName, revision, installationType, err := c.getWindowsVersion()
 	if err != nil {
 		return fmt.Errorf("failed to get Windows version: %w", err)
@@ -88,13 +369,25 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 
 	c.installTimeTimestamp = installTimeTimestamp
 
-	version := osversion.Get()
+	firmwareType, err := kernel32.GetFirmwareType()
+	if err != nil {
+		c.logger.Debug("failed to get firmware type",
+			slog.Any("err", err),
+		)
+	}
 
-	// Microsoft has decided to keep the major version as "10" for Windows 11, including the product name.
-	if version.Build >= osversion.V21H2Win11 {
-		productName = strings.Replace(productName, " 10 ", " 11 ", 1)
+	// Values other than BIOS and UEFI are reported as unknown.
+	switch firmwareType {
+	case kernel32.FirmwareTypeBios, kernel32.FirmwareTypeUefi:
+		c.firmwareTypeValue = float64(firmwareType)
+	default:
+		c.firmwareTypeValue = float64(kernel32.FirmwareTypeUnknown)
 	}
 
+	version := osversion.Get()
+
+	productName = windowsProductName(productName, version)
+
 	c.osInformation = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		`Contains full product name & version in labels. Note that the "major_version" for Windows 11 is \"10\"; a build number greater than 22000 represents Windows 11.`,
@@ -107,27 +400,186 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 			"build_number":      strconv.FormatUint(uint64(version.Build), 10),
 			"revision":          revision,
 			"installation_type": installationType,
+			"product_type":      version.ProductType.String(),
+			"release":           version.Release(),
 		},
 	)
 
-	c.hostname = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "hostname"),
+	c.hostname = bdf.NewDesc(
+		Name,
+		"hostname",
 		"Labelled system hostname information as provided by ComputerSystem.DNSHostName and ComputerSystem.Domain",
 		[]string{
 			"hostname",
 			"domain",
 			"fqdn",
 		},
+	)
+
+	c.hostnameResolutionErrorsTotal = bdf.NewDesc(
+		Name,
+		"hostname_resolution_errors_total",
+		"Number of failed GetComputerName calls, including failures which succeeded on retry",
 		nil,
 	)
 
-	c.installTime = prometheus.NewDesc(
-		prometheus.BuildFQName(types.Namespace, Name, "install_time_timestamp"),
+	c.installTime = bdf.NewDesc(
+		Name,
+		"install_time_timestamp",
 		"Unix timestamp of OS installation time",
 		nil,
+	)
+
+	c.totalHandleCount = bdf.NewDesc(
+		Name,
+		"total_handle_count",
+		"Total number of handles opened by all processes, as provided by GetPerformanceInfo",
+		nil,
+	)
+
+	c.commitCharge = bdf.NewDesc(
+		Name,
+		"commit_charge_bytes",
+		"Amount of virtual memory committed by the system, as provided by GlobalMemoryStatusEx (ullTotalPageFile - ullAvailPageFile)",
+		nil,
+	)
+
+	c.commitLimit = bdf.NewDesc(
+		Name,
+		"commit_limit_bytes",
+		"Maximum amount of virtual memory the system can commit, as provided by GlobalMemoryStatusEx (ullTotalPageFile)",
+		nil,
+	)
+
+	c.activationStatus = bdf.NewDesc(
+		Name,
+		"activation_status",
+		"License status of the Windows installation, as provided by SoftwareLicensingProduct.LicenseStatus (0=Unlicensed, 1=Licensed, 2=OOBGrace, 3=OOTGrace, 4=NonGenuineGrace, 5=Notification, 6=ExtendedGrace)",
+		nil,
+	)
+
+	c.physicalDiskInfo = bdf.NewDesc(
+		Name,
+		"physical_disk_info",
+		"Serial number, firmware revision and model of a physical disk, as provided by Win32_DiskDrive",
+		[]string{"serial", "firmware", "model"},
+	)
+
+	c.physicalDiskCount = bdf.NewDesc(
+		Name,
+		"physical_disk_count",
+		"Number of physical disks, as provided by Win32_DiskDrive",
+		nil,
+	)
+
+	c.volumeCount = bdf.NewDesc(
+		Name,
+		"volume_count",
+		"Number of volumes, as provided by Win32_Volume",
+		nil,
+	)
+
+	c.powerPlanInfo = bdf.NewDesc(
+		Name,
+		"power_plan_info",
+		"Active power plan, as provided by PowerGetActiveScheme",
+		[]string{"name", "guid"},
+	)
+
+	c.powerPlanProcessorMinimumState = bdf.NewDesc(
+		Name,
+		"power_plan_processor_minimum_state_percent",
+		"Minimum processor state of the active power plan for the current power source",
+		nil,
+	)
+
+	c.powerPlanProcessorMaximumState = bdf.NewDesc(
+		Name,
+		"power_plan_processor_maximum_state_percent",
+		"Maximum processor state of the active power plan for the current power source",
+		nil,
+	)
+
+	c.defenderEngineVersion = bdf.NewDesc(
+		Name,
+		"defender_engine_version_info",
+		"Version of the Windows Defender antimalware engine, as provided by MSFT_MpComputerStatus.AMEngineVersion",
+		[]string{"version"},
+	)
+
+	c.firmwareType = bdf.NewDesc(
+		Name,
+		"firmware_type",
+		"Firmware type of the system, as provided by GetFirmwareType (0=Unknown, 1=BIOS, 2=UEFI)",
+		nil,
+	)
+
+	c.policy = bdf.NewDesc(
+		Name,
+		"policy",
+		"Value of a configured registry policy value. Not exposed if the policy value is not set.",
+		[]string{"path", "value_name", "value"},
+	)
+
+	c.automaticMaintenanceLastRun = bdf.NewDesc(
+		Name,
+		"automatic_maintenance_last_run_timestamp_seconds",
+		"Unix timestamp of the last run of the Windows automatic maintenance task, as provided by the Task Scheduler cache",
+		nil,
+	)
+
+	c.secureChannelHealthy = bdf.NewDesc(
+		Name,
+		"secure_channel_healthy",
+		"1 if the last verification of the secure channel of the computer account to its domain succeeded, 0 otherwise",
+		nil,
+	)
+
+	c.secureChannelInfo = bdf.NewDesc(
+		Name,
+		"secure_channel_info",
+		"Domain and domain controller of the secure channel of the computer account, as of the last verification",
+		[]string{"domain", "dc"},
+	)
+
+	c.installedApplicationsCount = bdf.NewDesc(
+		Name,
+		"installed_applications_count",
+		"Number of applications listed in the Uninstall registry keys of the 64-bit and 32-bit registry view, excluding system components",
 		nil,
 	)
 
+	c.installedApplicationInfo = bdf.NewDesc(
+		Name,
+		"installed_application_info",
+		"Display name and version of an installed application matching collector.os.installed-applications.include",
+		[]string{"name", "version"},
+	)
+
+	c.werReports = bdf.NewDesc(
+		Name,
+		"wer_reports",
+		"Number of Windows Error Reporting reports in the report queue and archive and of kernel minidumps. Decreases if reports or dumps are deleted.",
+		[]string{"source"},
+	)
+
+	c.kernelDumpLastTimestamp = bdf.NewDesc(
+		Name,
+		"kernel_dump_last_timestamp_seconds",
+		"Unix timestamp of the most recent kernel memory dump or minidump. Not exposed if there is no dump.",
+		nil,
+	)
+
+	if c.config.SecureChannelCheckInterval > 0 {
+		var ctx context.Context
+
+		ctx, c.ctxCancelFn = context.WithCancel(context.Background())
+
+		c.backgroundWorker.Go(func() {
+			c.runSecureChannelCheck(ctx)
+		})
+	}
+
 	return nil
 }
 
@@ -148,25 +600,81 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
 		c.installTimeTimestamp,
 	)
 
+	ch <- prometheus.MustNewConstMetric(
+		c.firmwareType,
+		prometheus.GaugeValue,
+		c.firmwareTypeValue,
+	)
+
 	if err := c.collectHostname(ch); err != nil {
 		errs = append(errs, fmt.Errorf("failed to collect hostname metrics: %w", err))
 	}
 
+	ch <- prometheus.MustNewConstMetric(
+		c.hostnameResolutionErrorsTotal,
+		prometheus.CounterValue,
+		float64(c.hostnameResolutionErrors.Load()),
+	)
+
+	if err := c.collectSystemHandleCount(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect handle count metrics: %w", err))
+	}
+
+	if err := c.collectCommitCharge(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect commit charge metrics: %w", err))
+	}
+
+	if err := c.collectInventory(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect inventory metrics: %w", err))
+	}
+
+	c.collectPowerPlan(ch)
+
+	if err := c.collectEffectivePolicySentinels(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect policy metrics: %w", err))
+	}
+
+	c.collectSecureChannel(ch)
+
+	if err := c.collectInstalledApplications(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect installed applications metrics: %w", err))
+	}
+
+	if err := c.collectCrashDumps(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect crash dump metrics: %w", err))
+	}
+
+	if err := c.collectMaintenanceLastRun(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect automatic maintenance metrics: %w", err))
+	}
+
+	c.wmiQueryDuration.Collect(ch)
+
 	return errors.Join(errs...)
 }
 
+// queryWMI runs the WMI query and observes its duration, including failed queries.
+func (c *Collector) queryWMI(dst any, namespace mi.Namespace, query mi.Query, class string) error {
+	start := time.Now()
+	err := c.miSession.Query(dst, namespace, query)
+
+	c.wmiQueryDuration.WithLabelValues(class).Observe(time.Since(start).Seconds())
+
+	return err
+}
+
 func (c *Collector) collectHostname(ch chan<- prometheus.Metric) error {
-	hostname, err := sysinfoapi.GetComputerName(sysinfoapi.ComputerNameDNSHostname)
+	hostname, err := c.computerName(sysinfoapi.ComputerNameDNSHostname)
 	if err != nil {
 		return err
 	}
 
-	domain, err := sysinfoapi.GetComputerName(sysinfoapi.ComputerNameDNSDomain)
+	domain, err := c.computerName(sysinfoapi.ComputerNameDNSDomain)
 	if err != nil {
 		return err
 	}
 
-	fqdn, err := sysinfoapi.GetComputerName(sysinfoapi.ComputerNameDNSFullyQualified)
+	fqdn, err := c.computerName(sysinfoapi.ComputerNameDNSFullyQualified)
 	if err != nil {
 		return err
 	}
@@ -183,14 +691,342 @@ func (c *Collector) collectHostname(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
+// computerName returns the computer name in the given format. GetComputerName can fail transiently
+// while the network configuration changes, therefore failed calls are retried.
+func (c *Collector) computerName(format sysinfoapi.WinComputerNameFormat) (string, error) {
+	var err error
+
+	for attempt := range hostnameResolutionAttempts {
+		if attempt > 0 {
+			time.Sleep(hostnameResolutionBackoff)
+		}
+
+		var name string
+
+		name, err = c.getComputerName(format)
+		if err == nil {
+			return name, nil
+		}
+
+		c.hostnameResolutionErrors.Add(1)
+	}
+
+	return "", fmt.Errorf("GetComputerName failed after %d attempts: %w", hostnameResolutionAttempts, err)
+}
+
+// collectSystemHandleCount reads the total number of handles from GetPerformanceInfo.
+// Unlike summing Win32_Process.HandleCount, this does not enumerate the processes via WMI on each scrape.
+func (c *Collector) collectSystemHandleCount(ch chan<- prometheus.Metric) error {
+	performanceInfo, err := psapi.GetPerformanceInfo()
+	if err != nil {
+		return err
+	}
+
+	handleCount := uint64(performanceInfo.HandleCount)
+
+	if c.config.HandleCountWarningThreshold > 0 && handleCount > c.config.HandleCountWarningThreshold {
+		c.logger.Warn("total handle count exceeds the configured threshold",
+			slog.Uint64("handle_count", handleCount),
+			slog.Uint64("threshold", c.config.HandleCountWarningThreshold),
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.totalHandleCount,
+		prometheus.GaugeValue,
+		float64(handleCount),
+	)
+
+	return nil
+}
+
+func (c *Collector) collectCommitCharge(ch chan<- prometheus.Metric) error {
+	memoryStatus, err := sysinfoapi.GlobalMemoryStatusEx()
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.commitCharge,
+		prometheus.GaugeValue,
+		float64(memoryStatus.TotalPageFile-memoryStatus.AvailPageFile),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.commitLimit,
+		prometheus.GaugeValue,
+		float64(memoryStatus.TotalPageFile),
+	)
+
+	return nil
+}
+
+// collectPowerPlan collects the active power plan. Errors are logged only,
+// since the power service is not available on every host.
+func (c *Collector) collectPowerPlan(ch chan<- prometheus.Metric) {
+	scheme, err := powrprof.PowerGetActiveScheme()
+	if err != nil {
+		c.logger.Debug("failed to get active power plan",
+			slog.Any("err", err),
+		)
+
+		return
+	}
+
+	name, err := powrprof.PowerReadFriendlyName(&scheme)
+	if err != nil {
+		c.logger.Debug("failed to get power plan name",
+			slog.Any("err", err),
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.powerPlanInfo,
+		prometheus.GaugeValue,
+		1.0,
+		name,
+		strings.ToLower(strings.Trim(scheme.String(), "{}")),
+	)
+
+	readValueIndex := powrprof.PowerReadACValueIndex
+	if status, err := kernel32.GetSystemPowerStatus(); err == nil && status.ACLineStatus == kernel32.ACLineStatusOffline {
+		readValueIndex = powrprof.PowerReadDCValueIndex
+	}
+
+	for desc, setting := range map[*prometheus.Desc]*windows.GUID{
+		c.powerPlanProcessorMinimumState: &powrprof.GUID_PROCESSOR_THROTTLE_MINIMUM,
+		c.powerPlanProcessorMaximumState: &powrprof.GUID_PROCESSOR_THROTTLE_MAXIMUM,
+	} {
+		value, err := readValueIndex(&scheme, &powrprof.GUID_PROCESSOR_SETTINGS_SUBGROUP, setting)
+		if err != nil {
+			c.logger.Debug("failed to read processor power setting",
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			float64(value),
+		)
+	}
+}
+
+// policyKeyPrefixes are the registry keys below which policy values may be configured.
+//
+//nolint:gochecknoglobals
+var policyKeyPrefixes = []string{
+	`SOFTWARE\Policies\`,
+	`SOFTWARE\Microsoft\Windows\CurrentVersion\Policies\`,
+}
+
+// policyValue is a registry value configured via --collector.os.policy-values.
+type policyValue struct {
+	root      registry.Key
+	path      string // path including the root key, e.g. HKLM\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU
+	keyPath   string // path below the root key
+	valueName string
+}
+
+// parsePolicyValue parses a policy value in the format <HKLM|HKCU>\<key path>\<value name>.
+func parsePolicyValue(entry string) (policyValue, error) {
+	rootName, rest, ok := strings.Cut(entry, `\`)
+	if !ok {
+		return policyValue{}, fmt.Errorf("invalid policy value %q: expected <HKLM|HKCU>\\<key path>\\<value name>", entry)
+	}
+
+	var root registry.Key
+
+	switch strings.ToUpper(rootName) {
+	case "HKLM", "HKEY_LOCAL_MACHINE":
+		root = registry.LOCAL_MACHINE
+	case "HKCU", "HKEY_CURRENT_USER":
+		root = registry.CURRENT_USER
+	default:
+		return policyValue{}, fmt.Errorf("invalid policy value %q: unsupported root key %s. Possible values: HKLM, HKCU", entry, rootName)
+	}
+
+	idx := strings.LastIndex(rest, `\`)
+	if idx <= 0 || idx == len(rest)-1 {
+		return policyValue{}, fmt.Errorf("invalid policy value %q: expected <HKLM|HKCU>\\<key path>\\<value name>", entry)
+	}
+
+	keyPath, valueName := rest[:idx], rest[idx+1:]
+
+	if !slices.ContainsFunc(policyKeyPrefixes, func(prefix string) bool {
+		return len(keyPath) >= len(prefix) && strings.EqualFold(keyPath[:len(prefix)], prefix)
+	}) {
+		return policyValue{}, fmt.Errorf("invalid policy value %q: key is not below %s", entry, strings.Join(policyKeyPrefixes, " or "))
+	}
+
+	return policyValue{
+		root:      root,
+		path:      rootName + `\` + keyPath,
+		keyPath:   keyPath,
+		valueName: valueName,
+	}, nil
+}
+
+func openPolicyKey(root registry.Key, path string) (registryKey, error) {
+	return registry.OpenKey(root, path, registry.QUERY_VALUE)
+}
+
+// collectEffectivePolicySentinels exposes the configured policy values, which are applied by group policy.
+func (c *Collector) collectEffectivePolicySentinels(ch chan<- prometheus.Metric) error {
+	errs := make([]error, 0)
+
+	for _, policy := range c.policyValues {
+		value, ok, err := c.readPolicyValue(policy)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s\\%s: %w", policy.path, policy.valueName, err))
+
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.policy,
+			prometheus.GaugeValue,
+			1.0,
+			policy.path,
+			policy.valueName,
+			value,
+		)
+	}
+
+	return errors.Join(errs...)
+}
+
+// readPolicyValue reads a string or integer policy value. It returns false, if the key or value does not exist.
+func (c *Collector) readPolicyValue(policy policyValue) (string, bool, error) {
+	key, err := c.openPolicyKey(policy.root, policy.keyPath)
+	if errors.Is(err, registry.ErrNotExist) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, fmt.Errorf("failed to open registry key: %w", err)
+	}
+
+	defer func(key registryKey) {
+		_ = key.Close()
+	}(key)
+
+	value, _, err := key.GetStringValue(policy.valueName)
+	if errors.Is(err, registry.ErrUnexpectedType) {
+		var integerValue uint64
+
+		integerValue, _, err = key.GetIntegerValue(policy.valueName)
+		value = strconv.FormatUint(integerValue, 10)
+	}
+
+	if errors.Is(err, registry.ErrNotExist) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	return value, true, nil
+}
+
+// taskCacheKey is the registry key where the Task Scheduler caches its task definitions and run states.
+const taskCacheKey = `SOFTWARE\Microsoft\Windows NT\CurrentVersion\Schedule\TaskCache`
+
+// collectMaintenanceLastRun reads the last run time of the Regular Maintenance task.
+// The Tree key maps the task path to its ID, the Tasks key holds the run state of the task in the binary DynamicInfo value.
+func (c *Collector) collectMaintenanceLastRun(ch chan<- prometheus.Metric) error {
+	treeKey, err := registry.OpenKey(registry.LOCAL_MACHINE,
+		taskCacheKey+`\Tree\Microsoft\Windows\TaskScheduler\Regular Maintenance`,
+		registry.QUERY_VALUE,
+	)
+	if errors.Is(err, registry.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to open registry key: %w", err)
+	}
+
+	defer func(treeKey registry.Key) {
+		_ = treeKey.Close()
+	}(treeKey)
+
+	taskID, _, err := treeKey.GetStringValue("Id")
+	if err != nil {
+		return fmt.Errorf("failed to read task id: %w", err)
+	}
+
+	taskKey, err := registry.OpenKey(registry.LOCAL_MACHINE, taskCacheKey+`\Tasks\`+taskID, registry.QUERY_VALUE)
+	if err != nil {
+		return fmt.Errorf("failed to open registry key: %w", err)
+	}
+
+	defer func(taskKey registry.Key) {
+		_ = taskKey.Close()
+	}(taskKey)
+
+	dynamicInfo, _, err := taskKey.GetBinaryValue("DynamicInfo")
+	if err != nil {
+		return fmt.Errorf("failed to read task dynamic info: %w", err)
+	}
+
+	lastRun, ok := taskLastRunTime(dynamicInfo)
+	if !ok {
+		return nil
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.automaticMaintenanceLastRun,
+		prometheus.GaugeValue,
+		float64(lastRun.Unix()),
+	)
+
+	return nil
+}
+
+// taskLastRunTime returns the last run time stored in the DynamicInfo value of a cached task.
+// The value starts with a 4 byte version, followed by the creation and last run time as FILETIME.
+// It returns false, if the value is malformed or the task never ran.
+func taskLastRunTime(dynamicInfo []byte) (time.Time, bool) {
+	if len(dynamicInfo) < 20 {
+		return time.Time{}, false
+	}
+
+	filetime := windows.Filetime{
+		LowDateTime:  binary.LittleEndian.Uint32(dynamicInfo[12:16]),
+		HighDateTime: binary.LittleEndian.Uint32(dynamicInfo[16:20]),
+	}
+
+	if filetime.LowDateTime == 0 && filetime.HighDateTime == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, filetime.Nanoseconds()), true
+}
+
+// windowsProductName returns the product name for the given version.
+// Microsoft has decided to keep the major version as "10" for Windows 11, including the product name.
+func windowsProductName(productName string, version osversion.OSVersion) string {
+	if version.ProductType == osversion.ProductTypeClient && version.AtLeast(osversion.V21H2Win11) {
+		return strings.Replace(productName, " 10 ", " 11 ", 1)
+	}
+
+	return productName
+}
+
+func openCurrentVersionKey() (registryKey, error) {
+	return registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, registry.QUERY_VALUE)
+}
+
 func (c *Collector) getWindowsVersion() (string, string, string, error) {
 	// Get build number and product name from registry
-	ntKey, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, registry.QUERY_VALUE)
+	ntKey, err := c.openCurrentVersionKey()
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to open registry key: %w", err)
 	}
 
-	defer func(ntKey registry.Key) {
+	defer func(ntKey registryKey) {
 		_ = ntKey.Close()
 	}(ntKey)
 
@@ -215,12 +1051,12 @@ func (c *Collector) getWindowsVersion() (string, string, string, error) {
 }
 
 func (c *Collector) getInstallTime() (float64, error) {
-	ntKey, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, registry.QUERY_VALUE)
+	ntKey, err := c.openCurrentVersionKey()
 	if err != nil {
 		return 0, fmt.Errorf("failed to open registry key: %w", err)
 	}
 
-	defer func(ntKey registry.Key) {
+	defer func(ntKey registryKey) {
 		_ = ntKey.Close()
 	}(ntKey)
 
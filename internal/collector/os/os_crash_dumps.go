@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	crashControlKeyPath = `SYSTEM\CurrentControlSet\Control\CrashControl`
+
+	// crashDumpsRefreshInterval is the interval in which the crash report and dump directories are listed.
+	crashDumpsRefreshInterval = 5 * time.Minute
+
+	// crashDumpsMaxEntries limits the number of directory entries listed per directory.
+	crashDumpsMaxEntries = 10000
+)
+
+// crashDumpPaths are the directories and files inspected for crash reports and dumps.
+type crashDumpPaths struct {
+	werReportQueue   string
+	werReportArchive string
+	minidump         string
+	dumpFile         string
+}
+
+// crashDumps is the result of listing the crash report and dump directories.
+type crashDumps struct {
+	// reports is the number of entries by source label.
+	reports map[string]float64
+	// lastKernelDump is the modification time of the most recent kernel dump, zero if there is none.
+	lastKernelDump time.Time
+}
+
+// defaultCrashDumpPaths returns the Windows Error Reporting directories and the dump locations
+// configured in the CrashControl registry key.
+func defaultCrashDumpPaths() crashDumpPaths {
+	paths := crashDumpPaths{
+		werReportQueue:   expandPath(`%ProgramData%\Microsoft\Windows\WER\ReportQueue`),
+		werReportArchive: expandPath(`%ProgramData%\Microsoft\Windows\WER\ReportArchive`),
+		minidump:         expandPath(`%SystemRoot%\Minidump`),
+		dumpFile:         expandPath(`%SystemRoot%\MEMORY.DMP`),
+	}
+
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, crashControlKeyPath, registry.QUERY_VALUE)
+	if err != nil {
+		return paths
+	}
+
+	defer key.Close()
+
+	if value, _, err := key.GetStringValue("MinidumpDir"); err == nil && value != "" {
+		paths.minidump = expandPath(value)
+	}
+
+	if value, _, err := key.GetStringValue("DumpFile"); err == nil && value != "" {
+		paths.dumpFile = expandPath(value)
+	}
+
+	return paths
+}
+
+func expandPath(path string) string {
+	expanded, err := registry.ExpandString(path)
+	if err != nil {
+		return path
+	}
+
+	return expanded
+}
+
+// crashDumpPaths returns the default paths overridden by the configured paths.
+func (c *Collector) crashDumpPaths() crashDumpPaths {
+	paths := defaultCrashDumpPaths()
+
+	if c.config.WERReportQueuePath != "" {
+		paths.werReportQueue = c.config.WERReportQueuePath
+	}
+
+	if c.config.WERReportArchivePath != "" {
+		paths.werReportArchive = c.config.WERReportArchivePath
+	}
+
+	if c.config.MinidumpPath != "" {
+		paths.minidump = c.config.MinidumpPath
+	}
+
+	return paths
+}
+
+// readCrashDumps counts the entries of the crash report and dump directories. Each WER report is a
+// directory in the report queue or archive. Only the directory entries are listed, no file is read.
+// Missing directories are counted as empty.
+func readCrashDumps(paths crashDumpPaths, limit int) (crashDumps, error) {
+	dumps := crashDumps{
+		reports: make(map[string]float64, 3),
+	}
+
+	var errs []error
+
+	for source, dir := range map[string]string{
+		"report_queue":   paths.werReportQueue,
+		"report_archive": paths.werReportArchive,
+	} {
+		count, _, err := listDirectory(dir, limit, func(fs.DirEntry) bool { return true })
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		dumps.reports[source] = float64(count)
+	}
+
+	count, lastMinidump, err := listDirectory(paths.minidump, limit, func(entry fs.DirEntry) bool {
+		return !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".dmp")
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	dumps.reports["minidump"] = float64(count)
+	dumps.lastKernelDump = lastMinidump
+
+	if info, err := os.Stat(paths.dumpFile); err == nil && info.ModTime().After(dumps.lastKernelDump) {
+		dumps.lastKernelDump = info.ModTime()
+	}
+
+	return dumps, errors.Join(errs...)
+}
+
+// listDirectory returns the number of entries of dir matching match and the most recent modification time
+// of these entries. At most limit entries are listed.
+func listDirectory(dir string, limit int, match func(fs.DirEntry) bool) (int, time.Time, error) {
+	f, err := os.Open(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, time.Time{}, nil
+	} else if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to open directory %s: %w", dir, err)
+	}
+
+	defer f.Close()
+
+	var (
+		count  int
+		latest time.Time
+	)
+
+	for listed := 0; listed < limit; {
+		entries, err := f.ReadDir(min(limit-listed, 256))
+		listed += len(entries)
+
+		for _, entry := range entries {
+			if !match(entry) {
+				continue
+			}
+
+			count++
+
+			// On Windows, the file info is part of the directory listing and does not require a stat call.
+			if info, err := entry.Info(); err == nil && info.ModTime().After(latest) {
+				latest = info.ModTime()
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return count, latest, fmt.Errorf("failed to read directory %s: %w", dir, err)
+		}
+	}
+
+	return count, latest, nil
+}
+
+// refreshCrashDumps lists the crash report and dump directories, if the cached result is older than
+// crashDumpsRefreshInterval.
+func (c *Collector) refreshCrashDumps() error {
+	c.crashDumpsMu.Lock()
+	defer c.crashDumpsMu.Unlock()
+
+	if time.Since(c.crashDumpsLastRefresh) < crashDumpsRefreshInterval {
+		return nil
+	}
+
+	dumps, err := readCrashDumps(c.crashDumpPaths(), crashDumpsMaxEntries)
+
+	// Inaccessible directories are returned as error, the remaining directories are still exposed.
+	c.crashDumpsCache = dumps
+	c.crashDumpsLastRefresh = time.Now()
+
+	return err
+}
+
+func (c *Collector) collectCrashDumps(ch chan<- prometheus.Metric) error {
+	err := c.refreshCrashDumps()
+
+	c.crashDumpsMu.Lock()
+	defer c.crashDumpsMu.Unlock()
+
+	for source, count := range c.crashDumpsCache.reports {
+		ch <- prometheus.MustNewConstMetric(
+			c.werReports,
+			prometheus.GaugeValue,
+			count,
+			source,
+		)
+	}
+
+	if !c.crashDumpsCache.lastKernelDump.IsZero() {
+		ch <- prometheus.MustNewConstMetric(
+			c.kernelDumpLastTimestamp,
+			prometheus.GaugeValue,
+			float64(c.crashDumpsCache.lastKernelDump.UnixMicro())/1e6,
+		)
+	}
+
+	return err
+}
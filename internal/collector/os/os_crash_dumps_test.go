@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCrashDumps(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	paths := crashDumpPaths{
+		werReportQueue:   filepath.Join(root, "ReportQueue"),
+		werReportArchive: filepath.Join(root, "ReportArchive"),
+		minidump:         filepath.Join(root, "Minidump"),
+		dumpFile:         filepath.Join(root, "MEMORY.DMP"),
+	}
+
+	// Each WER report is a directory.
+	for _, report := range []string{"AppCrash_a.exe_1", "AppCrash_b.exe_2", "AppHang_c.exe_3"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(paths.werReportArchive, report), 0o755))
+	}
+
+	require.NoError(t, os.MkdirAll(paths.minidump, 0o755))
+
+	lastMinidump := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, name := range []string{"100124-1234-01.dmp", "093024-5678-01.DMP", "notes.txt"} {
+		path := filepath.Join(paths.minidump, name)
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+		require.NoError(t, os.Chtimes(path, lastMinidump, lastMinidump.Add(-time.Duration(i)*time.Hour)))
+	}
+
+	// The report queue and the kernel memory dump do not exist.
+	dumps, err := readCrashDumps(paths, 100)
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{
+		"report_queue":   0,
+		"report_archive": 3,
+		"minidump":       2,
+	}, dumps.reports)
+	require.True(t, lastMinidump.Equal(dumps.lastKernelDump))
+
+	lastDump := lastMinidump.Add(time.Hour)
+
+	require.NoError(t, os.WriteFile(paths.dumpFile, nil, 0o644))
+	require.NoError(t, os.Chtimes(paths.dumpFile, lastDump, lastDump))
+
+	dumps, err = readCrashDumps(paths, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2.0, dumps.reports["report_archive"])
+	require.True(t, lastDump.Equal(dumps.lastKernelDump))
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/sysinfoapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputerNameRetry(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name           string
+		failures       int
+		expected       string
+		expectedErrors uint64
+		expectErr      bool
+	}{
+		{
+			name:     "success",
+			expected: "PC",
+		},
+		{
+			name:           "transient failure",
+			failures:       2,
+			expected:       "PC",
+			expectedErrors: 2,
+		},
+		{
+			name:           "permanent failure",
+			failures:       hostnameResolutionAttempts,
+			expectedErrors: hostnameResolutionAttempts,
+			expectErr:      true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+
+			c := New(nil)
+			c.logger = slog.New(slog.DiscardHandler)
+			c.getComputerName = func(sysinfoapi.WinComputerNameFormat) (string, error) {
+				calls++
+
+				if calls <= tc.failures {
+					return "", errors.New("GetComputerNameEx: The specified network name is no longer available.")
+				}
+
+				return "PC", nil
+			}
+
+			name, err := c.computerName(sysinfoapi.ComputerNameDNSHostname)
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expected, name)
+			require.Equal(t, tc.expectedErrors, c.hostnameResolutionErrors.Load())
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	uninstallKeyPath = `SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`
+
+	// installedApplicationsRefreshInterval is the interval in which the Uninstall registry keys are read.
+	installedApplicationsRefreshInterval = 5 * time.Minute
+
+	// installedApplicationsMaxSeries limits the number of windows_os_installed_application_info series.
+	installedApplicationsMaxSeries = 100
+)
+
+type installedApplication struct {
+	name    string
+	version string
+}
+
+// readInstalledApplications reads the applications of the Uninstall registry key of the 64-bit and the 32-bit registry view.
+// System components, which are hidden in Programs and Features, are skipped.
+func readInstalledApplications() ([]installedApplication, error) {
+	applications := make([]installedApplication, 0)
+
+	for _, view := range []uint32{registry.WOW64_64KEY, registry.WOW64_32KEY} {
+		key, err := registry.OpenKey(registry.LOCAL_MACHINE, uninstallKeyPath, registry.ENUMERATE_SUB_KEYS|view)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open registry key %s: %w", uninstallKeyPath, err)
+		}
+
+		subKeyNames, err := key.ReadSubKeyNames(-1)
+
+		_ = key.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registry key %s: %w", uninstallKeyPath, err)
+		}
+
+		for _, subKeyName := range subKeyNames {
+			if application, ok := readInstalledApplication(uninstallKeyPath+`\`+subKeyName, view); ok {
+				applications = append(applications, application)
+			}
+		}
+	}
+
+	return applications, nil
+}
+
+func readInstalledApplication(path string, view uint32) (installedApplication, bool) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.QUERY_VALUE|view)
+	if err != nil {
+		return installedApplication{}, false
+	}
+
+	defer key.Close()
+
+	name, _, err := key.GetStringValue("DisplayName")
+	if err != nil || name == "" {
+		return installedApplication{}, false
+	}
+
+	if systemComponent, _, err := key.GetIntegerValue("SystemComponent"); err == nil && systemComponent == 1 {
+		return installedApplication{}, false
+	}
+
+	version, _, _ := key.GetStringValue("DisplayVersion")
+
+	return installedApplication{name: name, version: version}, true
+}
+
+// filterInstalledApplications removes duplicate applications and returns the number of applications
+// and the applications matching include, sorted by name and version. At most limit applications are returned.
+func filterInstalledApplications(applications []installedApplication, include *regexp.Regexp, limit int) (int, []installedApplication, bool) {
+	applications = slices.Clone(applications)
+
+	slices.SortFunc(applications, func(a, b installedApplication) int {
+		return cmp.Or(cmp.Compare(a.name, b.name), cmp.Compare(a.version, b.version))
+	})
+
+	applications = slices.Compact(applications)
+
+	matched := make([]installedApplication, 0)
+
+	for _, application := range applications {
+		if include.MatchString(application.name) {
+			matched = append(matched, application)
+		}
+	}
+
+	if len(matched) > limit {
+		return len(applications), matched[:limit], true
+	}
+
+	return len(applications), matched, false
+}
+
+// refreshInstalledApplications reads the installed applications, if the cached result is older than
+// installedApplicationsRefreshInterval. If the registry can't be read, the cached result is kept.
+func (c *Collector) refreshInstalledApplications() error {
+	c.installedApplicationsMu.Lock()
+	defer c.installedApplicationsMu.Unlock()
+
+	if time.Since(c.installedApplicationsLastRefresh) < installedApplicationsRefreshInterval {
+		return nil
+	}
+
+	applications, err := c.readInstalledApplications()
+	if err != nil {
+		return err
+	}
+
+	c.installedApplicationsLastRefresh = time.Now()
+
+	count, matched, truncated := filterInstalledApplications(applications, c.config.InstalledApplicationsInclude, installedApplicationsMaxSeries)
+	if truncated {
+		c.logger.Warn(fmt.Sprintf("more than %d installed applications match collector.os.installed-applications.include, only the first %d are exposed",
+			installedApplicationsMaxSeries, installedApplicationsMaxSeries),
+			slog.String("include", c.config.InstalledApplicationsInclude.String()),
+		)
+	}
+
+	c.installedApplicationsCountValue = float64(count)
+	c.installedApplicationsMatched = matched
+
+	return nil
+}
+
+func (c *Collector) collectInstalledApplications(ch chan<- prometheus.Metric) error {
+	err := c.refreshInstalledApplications()
+
+	c.installedApplicationsMu.Lock()
+	defer c.installedApplicationsMu.Unlock()
+
+	if c.installedApplicationsLastRefresh.IsZero() {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.installedApplicationsCount,
+		prometheus.GaugeValue,
+		c.installedApplicationsCountValue,
+	)
+
+	for _, application := range c.installedApplicationsMatched {
+		ch <- prometheus.MustNewConstMetric(
+			c.installedApplicationInfo,
+			prometheus.GaugeValue,
+			1.0,
+			application.name,
+			application.version,
+		)
+	}
+
+	return err
+}
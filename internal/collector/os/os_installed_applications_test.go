@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterInstalledApplications(t *testing.T) {
+	t.Parallel()
+
+	applications := []installedApplication{
+		{name: "Zabbix Agent 2 (64-bit)", version: "7.0.4"},
+		{name: "Microsoft Edge", version: "129.0.2792.79"},
+		{name: "CrowdStrike Sensor Platform", version: "7.16.18605.0"},
+		// The same application listed in the 64-bit and 32-bit registry view.
+		{name: "Microsoft Edge", version: "129.0.2792.79"},
+		{name: "Zabbix Agent", version: "6.4.0"},
+	}
+
+	include := regexp.MustCompile("^(?:Zabbix Agent.*|CrowdStrike.*)$")
+
+	count, matched, truncated := filterInstalledApplications(applications, include, 100)
+	require.Equal(t, 4, count)
+	require.False(t, truncated)
+	require.Equal(t, []installedApplication{
+		{name: "CrowdStrike Sensor Platform", version: "7.16.18605.0"},
+		{name: "Zabbix Agent", version: "6.4.0"},
+		{name: "Zabbix Agent 2 (64-bit)", version: "7.0.4"},
+	}, matched)
+
+	count, matched, truncated = filterInstalledApplications(applications, include, 2)
+	require.Equal(t, 4, count)
+	require.True(t, truncated)
+	require.Len(t, matched, 2)
+
+	_, matched, _ = filterInstalledApplications(applications, regexp.MustCompile("^(?:)$"), 100)
+	require.Empty(t, matched)
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// inventoryRefreshInterval is the interval in which the inventory WMI queries are run.
+// SoftwareLicensingProduct in particular may take several seconds to query.
+const inventoryRefreshInterval = 5 * time.Minute
+
+// softwareLicensingProduct represents the SoftwareLicensingProduct WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/sppwmi/softwarelicensingproduct
+type softwareLicensingProduct struct {
+	LicenseStatus uint32 `mi:"LicenseStatus"`
+}
+
+// win32DiskDrive represents the Win32_DiskDrive WMI class
+// - https://learn.microsoft.com/en-us/windows/win32/cimwin32prov/win32-diskdrive
+type win32DiskDrive struct {
+	Model            string `mi:"Model"`
+	SerialNumber     string `mi:"SerialNumber"`
+	FirmwareRevision string `mi:"FirmwareRevision"`
+}
+
+// win32Volume represents the Win32_Volume WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/legacy/aa394515(v=vs.85)
+type win32Volume struct {
+	DeviceID string `mi:"DeviceID"`
+}
+
+// msftMpComputerStatus represents the MSFT_MpComputerStatus WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/defender/msft-mpcomputerstatus
+type msftMpComputerStatus struct {
+	AMEngineVersion string `mi:"AMEngineVersion"`
+}
+
+// inventory is the cached result of the inventory WMI queries. The has* fields are false,
+// if the corresponding query never succeeded.
+type inventory struct {
+	activationStatus    uint32
+	hasActivationStatus bool
+
+	diskDrives    []win32DiskDrive
+	hasDiskDrives bool
+
+	volumeCount    int
+	hasVolumeCount bool
+
+	defenderStatus    []msftMpComputerStatus
+	hasDefenderStatus bool
+}
+
+// refreshInventory runs the inventory WMI queries, if the cached result is older than inventoryRefreshInterval.
+// If a query fails, its last result is kept and the query is retried on the next refresh.
+func (c *Collector) refreshInventory() error {
+	c.inventoryMu.Lock()
+	defer c.inventoryMu.Unlock()
+
+	if time.Since(c.inventoryLastRefresh) < inventoryRefreshInterval {
+		return nil
+	}
+
+	c.inventoryLastRefresh = time.Now()
+
+	errs := make([]error, 0)
+
+	var products []softwareLicensingProduct
+	if err := c.queryWMI(&products, mi.NamespaceRootCIMv2, c.activationMIQuery, "SoftwareLicensingProduct"); err != nil {
+		errs = append(errs, fmt.Errorf("failed to query activation status: %w", err))
+	} else {
+		// Without an installed Windows product key, there is no license status to expose.
+		c.inventoryCache.hasActivationStatus = len(products) > 0
+		if len(products) > 0 {
+			c.inventoryCache.activationStatus = products[0].LicenseStatus
+		}
+	}
+
+	var diskDrives []win32DiskDrive
+	if err := c.queryWMI(&diskDrives, mi.NamespaceRootCIMv2, c.diskDriveMIQuery, "Win32_DiskDrive"); err != nil {
+		errs = append(errs, fmt.Errorf("failed to query physical disks: %w", err))
+	} else {
+		c.inventoryCache.diskDrives = diskDrives
+		c.inventoryCache.hasDiskDrives = true
+	}
+
+	var volumes []win32Volume
+	if err := c.queryWMI(&volumes, mi.NamespaceRootCIMv2, c.volumeMIQuery, "Win32_Volume"); err != nil {
+		errs = append(errs, fmt.Errorf("failed to query volumes: %w", err))
+	} else {
+		c.inventoryCache.volumeCount = len(volumes)
+		c.inventoryCache.hasVolumeCount = true
+	}
+
+	if c.defenderEnabled {
+		var defenderStatus []msftMpComputerStatus
+		if err := c.queryWMI(&defenderStatus, mi.NamespaceRootWindowsDefender, c.defenderMIQuery, "MSFT_MpComputerStatus"); err != nil {
+			errs = append(errs, fmt.Errorf("failed to query defender status: %w", err))
+		} else {
+			c.inventoryCache.defenderStatus = defenderStatus
+			c.inventoryCache.hasDefenderStatus = true
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectInventory(ch chan<- prometheus.Metric) error {
+	err := c.refreshInventory()
+
+	c.inventoryMu.Lock()
+	defer c.inventoryMu.Unlock()
+
+	if c.inventoryCache.hasActivationStatus {
+		ch <- prometheus.MustNewConstMetric(
+			c.activationStatus,
+			prometheus.GaugeValue,
+			float64(c.inventoryCache.activationStatus),
+		)
+	}
+
+	if c.inventoryCache.hasDiskDrives {
+		for _, disk := range c.inventoryCache.diskDrives {
+			// Some drivers pad the serial number with spaces.
+			ch <- prometheus.MustNewConstMetric(
+				c.physicalDiskInfo,
+				prometheus.GaugeValue,
+				1.0,
+				strings.TrimSpace(disk.SerialNumber),
+				strings.TrimSpace(disk.FirmwareRevision),
+				strings.TrimSpace(disk.Model),
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.physicalDiskCount,
+			prometheus.GaugeValue,
+			float64(len(c.inventoryCache.diskDrives)),
+		)
+	}
+
+	if c.inventoryCache.hasVolumeCount {
+		ch <- prometheus.MustNewConstMetric(
+			c.volumeCount,
+			prometheus.GaugeValue,
+			float64(c.inventoryCache.volumeCount),
+		)
+	}
+
+	if c.inventoryCache.hasDefenderStatus {
+		for _, status := range c.inventoryCache.defenderStatus {
+			ch <- prometheus.MustNewConstMetric(
+				c.defenderEngineVersion,
+				prometheus.GaugeValue,
+				1.0,
+				status.AMEngineVersion,
+			)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/osversion"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+type fakeRegistryKey struct {
+	strings  map[string]string
+	integers map[string]uint64
+}
+
+func (k fakeRegistryKey) GetStringValue(name string) (string, uint32, error) {
+	value, ok := k.strings[name]
+	if !ok {
+		if _, ok := k.integers[name]; ok {
+			return "", registry.DWORD, registry.ErrUnexpectedType
+		}
+
+		return "", 0, registry.ErrNotExist
+	}
+
+	return value, registry.SZ, nil
+}
+
+func (k fakeRegistryKey) GetIntegerValue(name string) (uint64, uint32, error) {
+	value, ok := k.integers[name]
+	if !ok {
+		return 0, 0, registry.ErrNotExist
+	}
+
+	return value, registry.DWORD, nil
+}
+
+func (k fakeRegistryKey) Close() error {
+	return nil
+}
+
+func newCollectorWithRegistry(key fakeRegistryKey) *Collector {
+	c := New(nil)
+	c.openCurrentVersionKey = func() (registryKey, error) {
+		return key, nil
+	}
+
+	return c
+}
+
+func TestGetWindowsVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name                     string
+		key                      fakeRegistryKey
+		expectedProductName      string
+		expectedRevision         string
+		expectedInstallationType string
+		expectedErr              error
+	}{
+		{
+			name: "complete",
+			key: fakeRegistryKey{
+				strings:  map[string]string{"ProductName": "Windows Server 2022 Datacenter", "InstallationType": "Server"},
+				integers: map[string]uint64{"UBR": 2966},
+			},
+			expectedProductName:      "Windows Server 2022 Datacenter",
+			expectedRevision:         "2966",
+			expectedInstallationType: "Server",
+		},
+		{
+			name: "missing UBR",
+			key: fakeRegistryKey{
+				strings: map[string]string{"ProductName": "Windows 10 Pro", "InstallationType": "Client"},
+			},
+			expectedProductName:      "Windows 10 Pro",
+			expectedRevision:         "0",
+			expectedInstallationType: "Client",
+		},
+		{
+			name: "whitespace in ProductName",
+			key: fakeRegistryKey{
+				strings:  map[string]string{"ProductName": "  Windows 10 Pro \t", "InstallationType": " Client "},
+				integers: map[string]uint64{"UBR": 4842},
+			},
+			expectedProductName:      "Windows 10 Pro",
+			expectedRevision:         "4842",
+			expectedInstallationType: "Client",
+		},
+		{
+			name: "missing ProductName",
+			key: fakeRegistryKey{
+				strings: map[string]string{"InstallationType": "Client"},
+			},
+			expectedErr: registry.ErrNotExist,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			productName, revision, installationType, err := newCollectorWithRegistry(tc.key).getWindowsVersion()
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedProductName, productName)
+			require.Equal(t, tc.expectedRevision, revision)
+			require.Equal(t, tc.expectedInstallationType, installationType)
+		})
+	}
+}
+
+func TestGetInstallTime(t *testing.T) {
+	t.Parallel()
+
+	installTime, err := newCollectorWithRegistry(fakeRegistryKey{
+		integers: map[string]uint64{"InstallDate": 1672531200},
+	}).getInstallTime()
+	require.NoError(t, err)
+	require.InDelta(t, 1672531200, installTime, 0)
+
+	installTime, err = newCollectorWithRegistry(fakeRegistryKey{}).getInstallTime()
+	require.NoError(t, err)
+	require.Zero(t, installTime)
+}
+
+func TestWindowsProductName(t *testing.T) {
+	t.Parallel()
+
+	client := func(build uint16) osversion.OSVersion {
+		return osversion.OSVersion{Build: build, ProductType: osversion.ProductTypeClient}
+	}
+
+	server := func(build uint16) osversion.OSVersion {
+		return osversion.OSVersion{Build: build, ProductType: osversion.ProductTypeServer}
+	}
+
+	require.Equal(t, "Windows 10 Pro", windowsProductName("Windows 10 Pro", client(osversion.V22H2Win10)))
+	require.Equal(t, "Windows 11 Pro", windowsProductName("Windows 10 Pro", client(osversion.V21H2Win11)))
+	require.Equal(t, "Windows Server 2022 Datacenter", windowsProductName("Windows Server 2022 Datacenter", server(osversion.LTSC2022)))
+	require.Equal(t, "Windows Server 2025 Datacenter", windowsProductName("Windows Server 2025 Datacenter", server(osversion.LTSC2025)))
+}
+
+func TestTaskLastRunTime(t *testing.T) {
+	t.Parallel()
+
+	expected := time.Date(2024, 3, 10, 2, 0, 0, 0, time.UTC)
+	filetime := windows.NsecToFiletime(expected.UnixNano())
+
+	dynamicInfo := make([]byte, 28)
+	binary.LittleEndian.PutUint32(dynamicInfo[12:16], filetime.LowDateTime)
+	binary.LittleEndian.PutUint32(dynamicInfo[16:20], filetime.HighDateTime)
+
+	lastRun, ok := taskLastRunTime(dynamicInfo)
+	require.True(t, ok)
+	require.True(t, expected.Equal(lastRun))
+
+	_, ok = taskLastRunTime(make([]byte, 28))
+	require.False(t, ok, "task never ran")
+
+	_, ok = taskLastRunTime(dynamicInfo[:16])
+	require.False(t, ok, "truncated value")
+}
+
+func TestParsePolicyValue(t *testing.T) {
+	t.Parallel()
+
+	value, err := parsePolicyValue(`HKLM\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU\NoAutoUpdate`)
+	require.NoError(t, err)
+	require.Equal(t, registry.LOCAL_MACHINE, value.root)
+	require.Equal(t, `HKLM\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU`, value.path)
+	require.Equal(t, `SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU`, value.keyPath)
+	require.Equal(t, "NoAutoUpdate", value.valueName)
+
+	value, err = parsePolicyValue(`HKEY_CURRENT_USER\Software\Microsoft\Windows\CurrentVersion\Policies\System\DisableTaskMgr`)
+	require.NoError(t, err)
+	require.Equal(t, registry.CURRENT_USER, value.root)
+
+	for _, entry := range []string{
+		`HKLM`,
+		`HKU\SOFTWARE\Policies\Microsoft\Value`,
+		`HKLM\SOFTWARE\Microsoft\Windows NT\CurrentVersion\ProductName`,
+		`HKLM\SOFTWARE\Policies\`,
+		`HKLM\SOFTWARE\Policies\Microsoft\`,
+	} {
+		_, err := parsePolicyValue(entry)
+		require.Error(t, err, entry)
+	}
+}
+
+func TestReadPolicyValue(t *testing.T) {
+	t.Parallel()
+
+	c := New(nil)
+	c.openPolicyKey = func(_ registry.Key, path string) (registryKey, error) {
+		if path != `SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU` {
+			return nil, registry.ErrNotExist
+		}
+
+		return fakeRegistryKey{
+			strings:  map[string]string{"WUServer": "https://wsus:8531"},
+			integers: map[string]uint64{"NoAutoUpdate": 1},
+		}, nil
+	}
+
+	for _, tc := range []struct {
+		entry         string
+		expectedValue string
+		expectedOK    bool
+	}{
+		{entry: `HKLM\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU\NoAutoUpdate`, expectedValue: "1", expectedOK: true},
+		{entry: `HKLM\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU\WUServer`, expectedValue: "https://wsus:8531", expectedOK: true},
+		{entry: `HKLM\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU\AUOptions`},
+		{entry: `HKLM\SOFTWARE\Policies\Microsoft\Windows\Defender\DisableAntiSpyware`},
+	} {
+		policy, err := parsePolicyValue(tc.entry)
+		require.NoError(t, err)
+
+		value, ok, err := c.readPolicyValue(policy)
+		require.NoError(t, err)
+		require.Equal(t, tc.expectedOK, ok, tc.entry)
+		require.Equal(t, tc.expectedValue, value, tc.entry)
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	"unsafe"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+// secureChannelStatus is the result of the last secure channel verification.
+type secureChannelStatus struct {
+	domain  string
+	dc      string
+	healthy bool
+}
+
+// runSecureChannelCheck verifies the secure channel of the computer account to its domain
+// every SecureChannelCheckInterval, until ctx is cancelled.
+func (c *Collector) runSecureChannelCheck(ctx context.Context) {
+	for {
+		c.checkSecureChannel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.config.SecureChannelCheckInterval):
+		}
+	}
+}
+
+// checkSecureChannel verifies the secure channel and stores the result. A failed verification is reported as unhealthy.
+// If the computer is not joined to a domain, no result is stored.
+func (c *Collector) checkSecureChannel() {
+	domain, err := c.joinedDomain()
+	if err != nil {
+		c.logger.Debug("failed to get the domain of the computer",
+			slog.Any("err", err),
+		)
+
+		return
+	}
+
+	if domain == "" {
+		return
+	}
+
+	status := secureChannelStatus{domain: domain}
+
+	result, err := c.verifySecureChannel(domain)
+
+	switch {
+	case err != nil:
+		c.logger.Warn("failed to verify the secure channel",
+			slog.String("domain", domain),
+			slog.Any("err", err),
+		)
+	case result.Status != 0:
+		c.logger.Warn("secure channel is not healthy",
+			slog.String("domain", domain),
+			slog.String("dc", result.TrustedDCName),
+			slog.Any("status", windows.Errno(result.Status)),
+		)
+
+		status.dc = result.TrustedDCName
+	default:
+		status.dc = result.TrustedDCName
+		status.healthy = true
+	}
+
+	c.secureChannelMu.Lock()
+	c.secureChannel = &status
+	c.secureChannelMu.Unlock()
+}
+
+func (c *Collector) collectSecureChannel(ch chan<- prometheus.Metric) {
+	c.secureChannelMu.Lock()
+	status := c.secureChannel
+	c.secureChannelMu.Unlock()
+
+	if status == nil {
+		return
+	}
+
+	healthy := 0.0
+	if status.healthy {
+		healthy = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.secureChannelHealthy,
+		prometheus.GaugeValue,
+		healthy,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.secureChannelInfo,
+		prometheus.GaugeValue,
+		1.0,
+		status.domain,
+		status.dc,
+	)
+}
+
+// joinedDomain returns the NetBIOS name of the domain the computer is joined to, or an empty string
+// if the computer is not joined to a domain.
+func joinedDomain() (string, error) {
+	var (
+		name     *uint16
+		joinType uint32
+	)
+
+	if err := windows.NetGetJoinInformation(nil, &name, &joinType); err != nil {
+		return "", fmt.Errorf("NetGetJoinInformation: %w", err)
+	}
+
+	defer windows.NetApiBufferFree((*byte)(unsafe.Pointer(name))) //nolint:errcheck
+
+	if joinType != windows.NetSetupDomainName {
+		return "", nil
+	}
+
+	return windows.UTF16PtrToString(name), nil
+}
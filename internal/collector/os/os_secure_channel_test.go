@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/netapi32"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSecureChannel(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		domain   string
+		result   netapi32.SecureChannelStatus
+		err      error
+		expected *secureChannelStatus
+	}{
+		{
+			name:     "healthy",
+			domain:   "CONTOSO",
+			result:   netapi32.SecureChannelStatus{TrustedDCName: "dc01.contoso.com"},
+			expected: &secureChannelStatus{domain: "CONTOSO", dc: "dc01.contoso.com", healthy: true},
+		},
+		{
+			name:     "broken trust",
+			domain:   "CONTOSO",
+			result:   netapi32.SecureChannelStatus{TrustedDCName: "dc01.contoso.com", Status: 1787},
+			expected: &secureChannelStatus{domain: "CONTOSO", dc: "dc01.contoso.com"},
+		},
+		{
+			name:     "verification failed",
+			domain:   "CONTOSO",
+			err:      errors.New("I_NetLogonControl2: no logon servers"),
+			expected: &secureChannelStatus{domain: "CONTOSO"},
+		},
+		{
+			name:     "not domain joined",
+			expected: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := New(nil)
+			c.logger = slog.New(slog.DiscardHandler)
+			c.joinedDomain = func() (string, error) {
+				return tc.domain, nil
+			}
+			c.verifySecureChannel = func(domain string) (netapi32.SecureChannelStatus, error) {
+				require.Equal(t, tc.domain, domain)
+
+				return tc.result, tc.err
+			}
+
+			c.checkSecureChannel()
+
+			require.Equal(t, tc.expected, c.secureChannel)
+		})
+	}
+}
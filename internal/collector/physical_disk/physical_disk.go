@@ -33,14 +33,16 @@ import (
 const Name = "physical_disk"
 
 type Config struct {
-	DiskInclude *regexp.Regexp `yaml:"disk-include"`
-	DiskExclude *regexp.Regexp `yaml:"disk-exclude"`
+	DiskInclude     *regexp.Regexp `yaml:"disk-include"`
+	DiskExclude     *regexp.Regexp `yaml:"disk-exclude"`
+	InstancePattern string         `yaml:"instance-pattern"`
 }
 
 //nolint:gochecknoglobals
 var ConfigDefaults = Config{
-	DiskInclude: types.RegExpAny,
-	DiskExclude: types.RegExpEmpty,
+	DiskInclude:     types.RegExpAny,
+	DiskExclude:     types.RegExpEmpty,
+	InstancePattern: "",
 }
 
 // A Collector is a Prometheus Collector for perflib PhysicalDisk metrics.
@@ -101,6 +103,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Regexp of disks to include. Disk number must both match include and not match exclude to be included.",
 	).Default(".+").StringVar(&diskInclude)
 
+	app.Flag(
+		"collector.physical_disk.instance-pattern",
+		"PDH wildcard pattern of the PhysicalDisk instances to collect, e.g. \"0*\". Unlike disk-include, the instances are filtered by PDH. Defaults to all instances.",
+	).Default(ConfigDefaults.InstancePattern).StringVar(&c.config.InstancePattern)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		var err error
 
@@ -213,9 +220,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
-	var err error
+	instances, err := pdh.InstancesFromPattern(c.config.InstancePattern)
+	if err != nil {
+		return fmt.Errorf("collector.physical_disk.instance-pattern: %w", err)
+	}
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "PhysicalDisk", pdh.InstancesAll)
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "PhysicalDisk", instances, pdh.WithCollectorName(Name))
 	if err != nil {
 		return fmt.Errorf("failed to create PhysicalDisk collector: %w", err)
 	}
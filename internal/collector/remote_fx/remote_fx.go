@@ -232,12 +232,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	errs := make([]error, 0)
 
-	c.perfDataCollectorNetwork, err = pdh.NewCollector[perfDataCounterValuesNetwork](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "RemoteFX Network", pdh.InstancesAll)
+	c.perfDataCollectorNetwork, err = pdh.NewCollector[perfDataCounterValuesNetwork](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "RemoteFX Network", pdh.InstancesAll, pdh.WithCollectorName(Name))
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to create RemoteFX Network collector: %w", err))
 	}
 
-	c.perfDataCollectorGraphics, err = pdh.NewCollector[perfDataCounterValuesGraphics](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "RemoteFX Graphics", pdh.InstancesAll)
+	c.perfDataCollectorGraphics, err = pdh.NewCollector[perfDataCounterValuesGraphics](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "RemoteFX Graphics", pdh.InstancesAll, pdh.WithCollectorName(Name))
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to create RemoteFX Graphics collector: %w", err))
 	}
@@ -140,7 +140,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	}
 
 	// https://github.com/prometheus-community/windows_exporter/issues/1891
-	c.ppbCounterPresent = osversion.Build() >= osversion.LTSC2019
+	c.ppbCounterPresent = osversion.AtLeast(osversion.LTSC2019)
 
 	c.currentTime = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_timestamp_seconds"),
@@ -206,7 +206,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	if slices.Contains(c.config.CollectorsEnabled, collectorNTP) {
 		var err error
 
-		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "Windows Time Service", nil)
+		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "Windows Time Service", nil, pdh.WithCollectorName(Name))
 		if err != nil {
 			return fmt.Errorf("failed to create Windows Time Service collector: %w", err)
 		}
@@ -112,12 +112,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	var err error
 
-	c.perfDataCollector4, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "UDPv4", nil)
+	c.perfDataCollector4, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "UDPv4", nil, pdh.WithCollectorName(Name))
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to create UDPv4 collector: %w", err))
 	}
 
-	c.perfDataCollector6, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "UDPv6", nil)
+	c.perfDataCollector6, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "UDPv6", nil, pdh.WithCollectorName(Name))
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to create UDPv6 collector: %w", err))
 	}
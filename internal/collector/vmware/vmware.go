@@ -100,12 +100,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		errs []error
 	)
 
-	c.perfDataCollectorCPU, err = pdh.NewCollector[perfDataCounterValuesCPU](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "VM Processor", pdh.InstancesTotal)
+	c.perfDataCollectorCPU, err = pdh.NewCollector[perfDataCounterValuesCPU](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "VM Processor", pdh.InstancesTotal, pdh.WithCollectorName(Name))
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to create VM Processor collector: %w", err))
 	}
 
-	c.perfDataCollectorMemory, err = pdh.NewCollector[perfDataCounterValuesMemory](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "VM Memory", nil)
+	c.perfDataCollectorMemory, err = pdh.NewCollector[perfDataCounterValuesMemory](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "VM Memory", nil, pdh.WithCollectorName(Name))
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to create VM Memory collector: %w", err))
 	}
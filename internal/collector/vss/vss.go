@@ -0,0 +1,435 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package vss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/go-ole/go-ole"
+	"github.com/prometheus-community/windows_exporter/internal/headers/vssapi"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "vss"
+
+type Config struct {
+	ScrapeInterval time.Duration `yaml:"scrape_interval"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	ScrapeInterval: 5 * time.Minute,
+}
+
+// writerStates are the values of the state label of the windows_vss_writer_state metric.
+//
+//nolint:gochecknoglobals
+var writerStates = []string{"stable", "waiting", "failed", "unknown"}
+
+// A Collector is a Prometheus Collector for Volume Shadow Copy Service metrics.
+type Collector struct {
+	config    Config
+	logger    *slog.Logger
+	miSession *mi.Session
+
+	shadowCopyMIQuery    mi.Query
+	shadowStorageMIQuery mi.Query
+
+	// mu guards the fields below, which are updated by the writer status goroutine.
+	mu                sync.RWMutex
+	ctxCancelFn       context.CancelFunc
+	writerStatuses    []vssapi.WriterStatus
+	writerStatusErr   error
+	writerStatusTime  time.Time
+	writerStatusReady bool
+
+	writerState           *prometheus.Desc
+	writerLastError       *prometheus.Desc
+	writerStatusTimestamp *prometheus.Desc
+	shadowCopies          *prometheus.Desc
+	shadowStorageUsed     *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	app.Flag(
+		"collector.vss.scrape-interval",
+		"Define the interval of gathering the VSS writer status.",
+	).Default(ConfigDefaults.ScrapeInterval.String()).DurationVar(&c.config.ScrapeInterval)
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) Close() error {
+	if c.ctxCancelFn != nil {
+		c.ctxCancelFn()
+	}
+
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	c.miSession = miSession
+
+	var err error
+
+	c.shadowCopyMIQuery, err = mi.Select("VolumeName").From("Win32_ShadowCopy").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.shadowStorageMIQuery, err = mi.Select("Volume", "UsedSpace").From("Win32_ShadowStorage").Build()
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.writerState = bdf.NewDesc(
+		Name,
+		"writer_state",
+		"The state of the VSS writer, as provided by IVssBackupComponents::GatherWriterStatus",
+		[]string{"writer", "instance_id", "state"},
+	)
+	c.writerLastError = bdf.NewDesc(
+		Name,
+		"writer_last_error",
+		"The HRESULT of the last failure of the VSS writer. 0 if the writer did not fail.",
+		[]string{"writer", "instance_id"},
+	)
+	c.writerStatusTimestamp = bdf.NewDesc(
+		Name,
+		"writer_status_timestamp_seconds",
+		"Timestamp of the last successful gathering of the VSS writer status",
+		nil,
+	)
+	c.shadowCopies = bdf.NewDesc(
+		Name,
+		"shadow_copies",
+		"Number of shadow copies of the volume, as provided by Win32_ShadowCopy",
+		[]string{"volume"},
+	)
+	c.shadowStorageUsed = bdf.NewDesc(
+		Name,
+		"shadow_storage_used_bytes",
+		"Shadow storage used by the shadow copies of the volume, as provided by Win32_ShadowStorage.UsedSpace",
+		[]string{"volume"},
+	)
+
+	var dst []win32ShadowCopy
+	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.shadowCopyMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	initErrCh := make(chan error, 1)
+	go c.scheduleWriterStatus(ctx, initErrCh)
+
+	c.ctxCancelFn = cancel
+
+	if err := <-initErrCh; err != nil {
+		return fmt.Errorf("failed to initialize VSS collector: %w", err)
+	}
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) error {
+	errs := make([]error, 0)
+
+	if err := c.collectWriterStatus(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect VSS writer status: %w", err))
+	}
+
+	if err := c.collectShadowCopies(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect shadow copy metrics: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectWriterStatus(ch chan<- prometheus.Metric) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.writerStatusErr != nil {
+		return c.writerStatusErr
+	}
+
+	// The first gathering has not finished yet.
+	if !c.writerStatusReady {
+		return nil
+	}
+
+	for _, writer := range c.writerStatuses {
+		instanceID := writer.InstanceID.String()
+		state := writerState(writer.State)
+
+		for _, s := range writerStates {
+			isCurrentState := 0.0
+			if s == state {
+				isCurrentState = 1.0
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.writerState,
+				prometheus.GaugeValue,
+				isCurrentState,
+				writer.Name,
+				instanceID,
+				s,
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.writerLastError,
+			prometheus.GaugeValue,
+			float64(writer.FailureResult),
+			writer.Name,
+			instanceID,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.writerStatusTimestamp,
+		prometheus.GaugeValue,
+		float64(c.writerStatusTime.Unix()),
+	)
+
+	return nil
+}
+
+// win32ShadowCopy represents the Win32_ShadowCopy WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/vsswmi/win32-shadowcopy
+type win32ShadowCopy struct {
+	VolumeName string `mi:"VolumeName"`
+}
+
+// win32ShadowStorage represents the Win32_ShadowStorage WMI class
+// - https://learn.microsoft.com/en-us/previous-versions/windows/desktop/vsswmi/win32-shadowstorage
+type win32ShadowStorage struct {
+	// Volume is a reference to the Win32_Volume the shadow storage belongs to.
+	Volume struct {
+		DeviceID string `mi:"DeviceID"`
+	} `mi:"Volume"`
+	UsedSpace uint64 `mi:"UsedSpace"`
+}
+
+func (c *Collector) collectShadowCopies(ch chan<- prometheus.Metric) error {
+	var shadowCopies []win32ShadowCopy
+	if err := c.miSession.Query(&shadowCopies, mi.NamespaceRootCIMv2, c.shadowCopyMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var shadowStorages []win32ShadowStorage
+	if err := c.miSession.Query(&shadowStorages, mi.NamespaceRootCIMv2, c.shadowStorageMIQuery); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	shadowCopyCount := make(map[string]float64, len(shadowStorages))
+
+	// Volumes with shadow storage are reported, even if all shadow copies have been deleted.
+	for _, shadowStorage := range shadowStorages {
+		shadowCopyCount[shadowStorage.Volume.DeviceID] = 0
+	}
+
+	for _, shadowCopy := range shadowCopies {
+		shadowCopyCount[shadowCopy.VolumeName]++
+	}
+
+	for volume, count := range shadowCopyCount {
+		ch <- prometheus.MustNewConstMetric(
+			c.shadowCopies,
+			prometheus.GaugeValue,
+			count,
+			volume,
+		)
+	}
+
+	for _, shadowStorage := range shadowStorages {
+		ch <- prometheus.MustNewConstMetric(
+			c.shadowStorageUsed,
+			prometheus.GaugeValue,
+			float64(shadowStorage.UsedSpace),
+			shadowStorage.Volume.DeviceID,
+		)
+	}
+
+	return nil
+}
+
+// scheduleWriterStatus gathers the VSS writer status in the configured interval.
+// Gathering the status asks every writer for its metadata, which can take several seconds.
+func (c *Collector) scheduleWriterStatus(ctx context.Context, initErrCh chan<- error) {
+	// The VSS COM objects are bound to the thread that initialized COM.
+	runtime.LockOSThread()
+
+	defer runtime.UnlockOSThread()
+
+	if err := ole.CoInitializeEx(0, ole.COINIT_MULTITHREADED); err != nil {
+		var oleCode *ole.OleError
+		if errors.As(err, &oleCode) && oleCode.Code() != ole.S_OK && oleCode.Code() != 0x00000001 {
+			initErrCh <- fmt.Errorf("CoInitializeEx: %w", err)
+
+			return
+		}
+	}
+
+	defer ole.CoUninitialize()
+
+	close(initErrCh)
+
+	for {
+		writerStatuses, err := gatherWriterStatus()
+		if err != nil {
+			c.logger.LogAttrs(ctx, slog.LevelError, "failed to gather VSS writer status",
+				slog.Any("err", err),
+			)
+		}
+
+		c.mu.Lock()
+		c.writerStatuses = writerStatuses
+		c.writerStatusErr = err
+		c.writerStatusReady = true
+
+		if err == nil {
+			c.writerStatusTime = time.Now()
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-time.After(c.config.ScrapeInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func gatherWriterStatus() ([]vssapi.WriterStatus, error) {
+	backupComponents, err := vssapi.CreateVssBackupComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	defer backupComponents.Release()
+
+	if err := backupComponents.InitializeForBackup(); err != nil {
+		return nil, err
+	}
+
+	async, err := backupComponents.GatherWriterMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := async.Wait(); err != nil {
+		return nil, fmt.Errorf("GatherWriterMetadata: %w", err)
+	}
+
+	defer backupComponents.FreeWriterMetadata()
+
+	async, err = backupComponents.GatherWriterStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := async.Wait(); err != nil {
+		return nil, fmt.Errorf("GatherWriterStatus: %w", err)
+	}
+
+	defer backupComponents.FreeWriterStatus()
+
+	count, err := backupComponents.GetWriterStatusCount()
+	if err != nil {
+		return nil, err
+	}
+
+	writerStatuses := make([]vssapi.WriterStatus, 0, count)
+
+	for i := range count {
+		writerStatus, err := backupComponents.GetWriterStatus(i)
+		if err != nil {
+			return nil, err
+		}
+
+		writerStatuses = append(writerStatuses, writerStatus)
+	}
+
+	return writerStatuses, nil
+}
+
+// writerState maps a VSS_WRITER_STATE to the state label value.
+func writerState(state uint32) string {
+	switch state {
+	case vssapi.VSS_WS_STABLE:
+		return "stable"
+	case vssapi.VSS_WS_WAITING_FOR_FREEZE,
+		vssapi.VSS_WS_WAITING_FOR_THAW,
+		vssapi.VSS_WS_WAITING_FOR_POST_SNAPSHOT,
+		vssapi.VSS_WS_WAITING_FOR_BACKUP_COMPLETE:
+		return "waiting"
+	case vssapi.VSS_WS_FAILED_AT_IDENTIFY,
+		vssapi.VSS_WS_FAILED_AT_PREPARE_BACKUP,
+		vssapi.VSS_WS_FAILED_AT_PREPARE_SNAPSHOT,
+		vssapi.VSS_WS_FAILED_AT_FREEZE,
+		vssapi.VSS_WS_FAILED_AT_THAW,
+		vssapi.VSS_WS_FAILED_AT_POST_SNAPSHOT,
+		vssapi.VSS_WS_FAILED_AT_BACKUP_COMPLETE,
+		vssapi.VSS_WS_FAILED_AT_PRE_RESTORE,
+		vssapi.VSS_WS_FAILED_AT_POST_RESTORE,
+		vssapi.VSS_WS_FAILED_AT_BACKUPSHUTDOWN:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
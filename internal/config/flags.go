@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// maxFlagValueLength is the maximum length of a flag value exposed as label value. Longer values are hashed.
+	maxFlagValueLength = 64
+	redactedFlagValue  = "<redacted>"
+)
+
+// redactedFlags are flags whose values may contain credentials or point to them. Their values are never exposed.
+//
+//nolint:gochecknoglobals
+var redactedFlags = map[string]struct{}{
+	"push.gateway.url": {},
+	"web.config.file":  {},
+}
+
+// redactedFlagNameParts redact all flags whose name contains one of the parts, e.g. a basic auth password
+// or password file flag, in addition to redactedFlags.
+//
+//nolint:gochecknoglobals
+var redactedFlagNameParts = []string{"password", "secret", "token", "credential", "auth"}
+
+// ignoredFlags are flags which do not affect the behavior of the exporter.
+//
+//nolint:gochecknoglobals
+var ignoredFlags = map[string]struct{}{
+	"help":    {},
+	"version": {},
+}
+
+// FlagCollector exposes the effective value of each flag as windows_exporter_flag metric.
+type FlagCollector struct {
+	desc  *prometheus.Desc
+	flags map[string]string
+}
+
+// Interface guard.
+var _ prometheus.Collector = (*FlagCollector)(nil)
+
+// NewFlagCollector returns a FlagCollector for the flags of the application.
+// It must be called after Parse, so that values from the configuration file and the command line are applied.
+func NewFlagCollector(app *kingpin.Application) *FlagCollector {
+	flags := make(map[string]string)
+
+	for _, flag := range app.Model().Flags {
+		if _, ok := ignoredFlags[flag.Name]; ok || flag.Hidden {
+			continue
+		}
+
+		flags[flag.Name] = flagValue(flag.Name, flag.String())
+	}
+
+	return &FlagCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "flag"),
+			"Effective value of a windows_exporter flag, after applying the configuration file and command line. Credentials are redacted, long values are hashed.",
+			[]string{"name", "value"},
+			nil,
+		),
+		flags: flags,
+	}
+}
+
+func (c *FlagCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *FlagCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, value := range c.flags {
+		ch <- prometheus.MustNewConstMetric(
+			c.desc,
+			prometheus.GaugeValue,
+			1,
+			name,
+			value,
+		)
+	}
+}
+
+// flagValue returns the label value of a flag.
+func flagValue(name, value string) string {
+	if isRedactedFlag(name) && value != "" {
+		return redactedFlagValue
+	}
+
+	if len(value) > maxFlagValueLength {
+		sum := sha256.Sum256([]byte(value))
+
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	return value
+}
+
+// isRedactedFlag reports whether the value of the flag may contain credentials.
+func isRedactedFlag(name string) bool {
+	if _, ok := redactedFlags[name]; ok {
+		return true
+	}
+
+	name = strings.ToLower(name)
+
+	for _, part := range redactedFlagNameParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -32,6 +32,9 @@ var (
 	procGetTickCount                     = modkernel32.NewProc("GetTickCount64")
 	procOpenJobObject                    = modkernel32.NewProc("OpenJobObjectW")
 	procIsProcessInJob                   = modkernel32.NewProc("IsProcessInJob")
+	procGetSystemPowerStatus             = modkernel32.NewProc("GetSystemPowerStatus")
+	procGetFirmwareType                  = modkernel32.NewProc("GetFirmwareType")
+	procGetProcessHandleCount            = modkernel32.NewProc("GetProcessHandleCount")
 )
 
 // SYSTEMTIME contains a date and time.
@@ -87,3 +90,67 @@ func GetTickCount64() uint64 {
 
 	return uint64(ret)
 }
+
+// SystemPowerStatus contains information about the power status of the system.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/winbase/ns-winbase-system_power_status
+type SystemPowerStatus struct {
+	ACLineStatus        uint8
+	BatteryFlag         uint8
+	BatteryLifePercent  uint8
+	SystemStatusFlag    uint8
+	BatteryLifeTime     uint32
+	BatteryFullLifeTime uint32
+}
+
+// ACLineStatusOffline is the SystemPowerStatus.ACLineStatus value if the system runs on battery.
+const ACLineStatusOffline = 0
+
+// GetSystemPowerStatus retrieves the power status of the system.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/winbase/nf-winbase-getsystempowerstatus
+func GetSystemPowerStatus() (SystemPowerStatus, error) {
+	var status SystemPowerStatus
+
+	r0, _, err := procGetSystemPowerStatus.Call(uintptr(unsafe.Pointer(&status)))
+	if r0 == 0 {
+		return status, err
+	}
+
+	return status, nil
+}
+
+// FIRMWARE_TYPE values.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/winnt/ne-winnt-firmware_type
+const (
+	FirmwareTypeUnknown uint32 = 0
+	FirmwareTypeBios    uint32 = 1
+	FirmwareTypeUefi    uint32 = 2
+)
+
+// GetFirmwareType retrieves the firmware type of the system.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/winbase/nf-winbase-getfirmwaretype
+func GetFirmwareType() (uint32, error) {
+	var firmwareType uint32
+
+	r0, _, err := procGetFirmwareType.Call(uintptr(unsafe.Pointer(&firmwareType)))
+	if r0 == 0 {
+		return FirmwareTypeUnknown, err
+	}
+
+	return firmwareType, nil
+}
+
+// GetProcessHandleCount retrieves the number of open handles of the process.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/processthreadsapi/nf-processthreadsapi-getprocesshandlecount
+func GetProcessHandleCount(process windows.Handle) (uint32, error) {
+	var count uint32
+
+	r0, _, err := procGetProcessHandleCount.Call(
+		uintptr(process),
+		uintptr(unsafe.Pointer(&count)),
+	)
+	if r0 == 0 {
+		return 0, err
+	}
+
+	return count, nil
+}
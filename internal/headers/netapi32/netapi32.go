@@ -19,6 +19,8 @@ package netapi32
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -47,11 +49,36 @@ type WorkstationInfo struct {
 	LoggedOnUsers uint32
 }
 
+// netlogonInfo2 is a wrapper of NETLOGON_INFO_2
+// https://learn.microsoft.com/en-us/windows/win32/api/lmaccess/ns-lmaccess-netlogon_info_2
+type netlogonInfo2 struct {
+	netlog2_flags                 uint32
+	netlog2_pdc_connection_status uint32
+	netlog2_trusted_dc_name       *uint16
+	netlog2_tc_connection_status  uint32
+}
+
+// SecureChannelStatus is an idiomatic wrapper of the NETLOGON_INFO_2 result of NETLOGON_CONTROL_TC_VERIFY.
+type SecureChannelStatus struct {
+	// TrustedDCName is the name of the domain controller used for the secure channel, without leading backslashes.
+	TrustedDCName string
+	// Status is NERR_Success (0), if the secure channel is healthy.
+	Status uint32
+}
+
+const (
+	netlogonControlTCVerify = 10
+
+	// netlogonVerifyStatusReturned indicates that netlog2_pdc_connection_status contains the verification status.
+	netlogonVerifyStatusReturned = 0x80
+)
+
 //nolint:gochecknoglobals
 var (
-	netapi32             = windows.NewLazySystemDLL("netapi32")
-	procNetWkstaGetInfo  = netapi32.NewProc("NetWkstaGetInfo")
-	procNetApiBufferFree = netapi32.NewProc("NetApiBufferFree")
+	netapi32              = windows.NewLazySystemDLL("netapi32")
+	procNetWkstaGetInfo   = netapi32.NewProc("NetWkstaGetInfo")
+	procNetApiBufferFree  = netapi32.NewProc("NetApiBufferFree")
+	procINetLogonControl2 = netapi32.NewProc("I_NetLogonControl2")
 )
 
 // NetApiStatus is a map of Network Management Error Codes.
@@ -130,3 +157,40 @@ func GetWorkstationInfo() (WorkstationInfo, error) {
 
 	return workstationInfo, nil
 }
+
+// VerifySecureChannel verifies the secure channel of the local computer to the given domain.
+// The verification contacts a domain controller of the domain.
+// https://learn.microsoft.com/en-us/windows/win32/api/lmaccess/nf-lmaccess-i_netlogoncontrol2
+func VerifySecureChannel(domain string) (SecureChannelStatus, error) {
+	domainPtr, err := windows.UTF16PtrFromString(domain)
+	if err != nil {
+		return SecureChannelStatus{}, err
+	}
+
+	var info *netlogonInfo2
+
+	r1, _, _ := procINetLogonControl2.Call(
+		0,
+		netlogonControlTCVerify,
+		2,
+		uintptr(unsafe.Pointer(&domainPtr)),
+		uintptr(unsafe.Pointer(&info)),
+	)
+
+	if ret := uint32(r1); ret != 0 {
+		return SecureChannelStatus{}, fmt.Errorf("I_NetLogonControl2: %w", windows.Errno(ret))
+	}
+
+	defer windows.NetApiBufferFree((*byte)(unsafe.Pointer(info))) //nolint:errcheck
+
+	status := SecureChannelStatus{
+		TrustedDCName: strings.TrimPrefix(windows.UTF16PtrToString(info.netlog2_trusted_dc_name), `\\`),
+		Status:        info.netlog2_tc_connection_status,
+	}
+
+	if status.Status == 0 && info.netlog2_flags&netlogonVerifyStatusReturned != 0 {
+		status.Status = info.netlog2_pdc_connection_status
+	}
+
+	return status, nil
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package powrprof
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var (
+	modpowrprof = windows.NewLazySystemDLL("powrprof.dll")
+
+	procPowerGetActiveScheme  = modpowrprof.NewProc("PowerGetActiveScheme")
+	procPowerReadFriendlyName = modpowrprof.NewProc("PowerReadFriendlyName")
+	procPowerReadACValueIndex = modpowrprof.NewProc("PowerReadACValueIndex")
+	procPowerReadDCValueIndex = modpowrprof.NewProc("PowerReadDCValueIndex")
+)
+
+//nolint:gochecknoglobals
+var (
+	// GUID_PROCESSOR_SETTINGS_SUBGROUP is the power setting subgroup of the processor power management settings.
+	GUID_PROCESSOR_SETTINGS_SUBGROUP = windows.GUID{Data1: 0x54533251, Data2: 0x82be, Data3: 0x4824, Data4: [8]byte{0x96, 0xc1, 0x47, 0xb6, 0x0b, 0x74, 0x0d, 0x00}}
+	// GUID_PROCESSOR_THROTTLE_MINIMUM is the minimum processor state in percent.
+	GUID_PROCESSOR_THROTTLE_MINIMUM = windows.GUID{Data1: 0x893dee8e, Data2: 0x2bef, Data3: 0x41e0, Data4: [8]byte{0x89, 0xc6, 0xb5, 0x5d, 0x09, 0x29, 0x96, 0x4c}}
+	// GUID_PROCESSOR_THROTTLE_MAXIMUM is the maximum processor state in percent.
+	GUID_PROCESSOR_THROTTLE_MAXIMUM = windows.GUID{Data1: 0xbc5038f7, Data2: 0x23e0, Data3: 0x4960, Data4: [8]byte{0x96, 0xda, 0x33, 0xab, 0xaf, 0x59, 0x35, 0xec}}
+)
+
+// PowerGetActiveScheme retrieves the active power scheme.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/powersetting/nf-powersetting-powergetactivescheme
+func PowerGetActiveScheme() (windows.GUID, error) {
+	var activePolicyGUID *windows.GUID
+
+	r0, _, _ := procPowerGetActiveScheme.Call(
+		0,
+		uintptr(unsafe.Pointer(&activePolicyGUID)),
+	)
+	if r0 != uintptr(windows.ERROR_SUCCESS) {
+		return windows.GUID{}, windows.Errno(r0)
+	}
+
+	defer windows.LocalFree(windows.Handle(uintptr(unsafe.Pointer(activePolicyGUID)))) //nolint:errcheck
+
+	return *activePolicyGUID, nil
+}
+
+// PowerReadFriendlyName retrieves the friendly name of the power scheme.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/powrprof/nf-powrprof-powerreadfriendlyname
+func PowerReadFriendlyName(schemeGUID *windows.GUID) (string, error) {
+	var bufferSize uint32
+
+	r0, _, _ := procPowerReadFriendlyName.Call(
+		0,
+		uintptr(unsafe.Pointer(schemeGUID)),
+		0,
+		0,
+		0,
+		uintptr(unsafe.Pointer(&bufferSize)),
+	)
+	if r0 != uintptr(windows.ERROR_SUCCESS) {
+		return "", windows.Errno(r0)
+	}
+
+	if bufferSize == 0 {
+		return "", nil
+	}
+
+	buffer := make([]uint16, bufferSize/2)
+
+	r0, _, _ = procPowerReadFriendlyName.Call(
+		0,
+		uintptr(unsafe.Pointer(schemeGUID)),
+		0,
+		0,
+		uintptr(unsafe.Pointer(&buffer[0])),
+		uintptr(unsafe.Pointer(&bufferSize)),
+	)
+	if r0 != uintptr(windows.ERROR_SUCCESS) {
+		return "", windows.Errno(r0)
+	}
+
+	return windows.UTF16ToString(buffer), nil
+}
+
+// PowerReadACValueIndex retrieves the AC value of the power setting of the power scheme.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/powersetting/nf-powersetting-powerreadacvalueindex
+func PowerReadACValueIndex(schemeGUID, subGroupGUID, powerSettingGUID *windows.GUID) (uint32, error) {
+	return powerReadValueIndex(procPowerReadACValueIndex, schemeGUID, subGroupGUID, powerSettingGUID)
+}
+
+// PowerReadDCValueIndex retrieves the DC value of the power setting of the power scheme.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/powersetting/nf-powersetting-powerreaddcvalueindex
+func PowerReadDCValueIndex(schemeGUID, subGroupGUID, powerSettingGUID *windows.GUID) (uint32, error) {
+	return powerReadValueIndex(procPowerReadDCValueIndex, schemeGUID, subGroupGUID, powerSettingGUID)
+}
+
+func powerReadValueIndex(proc *windows.LazyProc, schemeGUID, subGroupGUID, powerSettingGUID *windows.GUID) (uint32, error) {
+	var valueIndex uint32
+
+	r0, _, _ := proc.Call(
+		0,
+		uintptr(unsafe.Pointer(schemeGUID)),
+		uintptr(unsafe.Pointer(subGroupGUID)),
+		uintptr(unsafe.Pointer(powerSettingGUID)),
+		uintptr(unsafe.Pointer(&valueIndex)),
+	)
+	if r0 != uintptr(windows.ERROR_SUCCESS) {
+		return 0, windows.Errno(r0)
+	}
+
+	return valueIndex, nil
+}
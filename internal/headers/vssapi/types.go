@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package vssapi
+
+import "github.com/go-ole/go-ole"
+
+// VSS_WRITER_STATE
+// - https://learn.microsoft.com/en-us/windows/win32/api/vss/ne-vss-vss_writer_state
+const (
+	VSS_WS_UNKNOWN                     = 0
+	VSS_WS_STABLE                      = 1
+	VSS_WS_WAITING_FOR_FREEZE          = 2
+	VSS_WS_WAITING_FOR_THAW            = 3
+	VSS_WS_WAITING_FOR_POST_SNAPSHOT   = 4
+	VSS_WS_WAITING_FOR_BACKUP_COMPLETE = 5
+	VSS_WS_FAILED_AT_IDENTIFY          = 6
+	VSS_WS_FAILED_AT_PREPARE_BACKUP    = 7
+	VSS_WS_FAILED_AT_PREPARE_SNAPSHOT  = 8
+	VSS_WS_FAILED_AT_FREEZE            = 9
+	VSS_WS_FAILED_AT_THAW              = 10
+	VSS_WS_FAILED_AT_POST_SNAPSHOT     = 11
+	VSS_WS_FAILED_AT_BACKUP_COMPLETE   = 12
+	VSS_WS_FAILED_AT_PRE_RESTORE       = 13
+	VSS_WS_FAILED_AT_POST_RESTORE      = 14
+	VSS_WS_FAILED_AT_BACKUPSHUTDOWN    = 15
+)
+
+// Status codes of IVssAsync::QueryStatus
+// - https://learn.microsoft.com/en-us/windows/win32/api/vss/nf-vss-ivssasync-querystatus
+const (
+	VSS_S_ASYNC_PENDING   = 0x00042309
+	VSS_S_ASYNC_FINISHED  = 0x0004230A
+	VSS_S_ASYNC_CANCELLED = 0x0004230B
+)
+
+type WriterStatus struct {
+	InstanceID    ole.GUID
+	WriterID      ole.GUID
+	Name          string
+	State         uint32
+	FailureResult uint32
+}
+
+type IVssBackupComponents struct {
+	lpVtbl *IVssBackupComponentsVtbl
+}
+
+type IVssBackupComponentsVtbl struct {
+	// IUnknown
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	// IVssBackupComponents
+	GetWriterComponentsCount uintptr
+	GetWriterComponents      uintptr
+	InitializeForBackup      uintptr
+	SetBackupState           uintptr
+	InitializeForRestore     uintptr
+	SetRestoreState          uintptr
+	GatherWriterMetadata     uintptr
+	GetWriterMetadataCount   uintptr
+	GetWriterMetadata        uintptr
+	FreeWriterMetadata       uintptr
+	AddComponent             uintptr
+	PrepareForBackup         uintptr
+	AbortBackup              uintptr
+	GatherWriterStatus       uintptr
+	GetWriterStatusCount     uintptr
+	FreeWriterStatus         uintptr
+	GetWriterStatus          uintptr
+}
+
+type IVssAsync struct {
+	lpVtbl *IVssAsyncVtbl
+}
+
+type IVssAsyncVtbl struct {
+	// IUnknown
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	// IVssAsync
+	Cancel      uintptr
+	Wait        uintptr
+	QueryStatus uintptr
+}
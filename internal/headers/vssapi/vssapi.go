@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package vssapi
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/go-ole/go-ole"
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var (
+	modVssapi = windows.NewLazySystemDLL("vssapi.dll")
+	// CreateVssBackupComponents is an inline function of vsbackup.h, which calls the exported CreateVssBackupComponentsInternal.
+	procCreateVssBackupComponentsInternal = modVssapi.NewProc("CreateVssBackupComponentsInternal")
+)
+
+// CreateVssBackupComponents creates a new IVssBackupComponents instance.
+// The caller must have initialized COM on the current thread and must call Release.
+// - https://learn.microsoft.com/en-us/windows/win32/api/vsbackup/nf-vsbackup-createvssbackupcomponents
+func CreateVssBackupComponents() (*IVssBackupComponents, error) {
+	var result *IVssBackupComponents
+
+	hr, _, _ := procCreateVssBackupComponentsInternal.Call(uintptr(unsafe.Pointer(&result)))
+	if hr != 0 {
+		return nil, fmt.Errorf("CreateVssBackupComponents failed: %w", windows.Errno(hr))
+	}
+
+	if result == nil {
+		return nil, errors.New("CreateVssBackupComponents returned nil")
+	}
+
+	return result, nil
+}
+
+func (b *IVssBackupComponents) InitializeForBackup() error {
+	hr, _, _ := syscall.SyscallN(
+		b.lpVtbl.InitializeForBackup,
+		uintptr(unsafe.Pointer(b)),
+		0,
+	)
+	if hr != 0 {
+		return fmt.Errorf("InitializeForBackup failed: %w", windows.Errno(hr))
+	}
+
+	return nil
+}
+
+// GatherWriterMetadata asks the writers to identify themselves. It has to be called before GatherWriterStatus.
+func (b *IVssBackupComponents) GatherWriterMetadata() (*IVssAsync, error) {
+	var async *IVssAsync
+
+	hr, _, _ := syscall.SyscallN(
+		b.lpVtbl.GatherWriterMetadata,
+		uintptr(unsafe.Pointer(b)),
+		uintptr(unsafe.Pointer(&async)),
+	)
+	if hr != 0 {
+		return nil, fmt.Errorf("GatherWriterMetadata failed: %w", windows.Errno(hr))
+	}
+
+	return async, nil
+}
+
+func (b *IVssBackupComponents) FreeWriterMetadata() {
+	_, _, _ = syscall.SyscallN(
+		b.lpVtbl.FreeWriterMetadata,
+		uintptr(unsafe.Pointer(b)),
+	)
+}
+
+func (b *IVssBackupComponents) GatherWriterStatus() (*IVssAsync, error) {
+	var async *IVssAsync
+
+	hr, _, _ := syscall.SyscallN(
+		b.lpVtbl.GatherWriterStatus,
+		uintptr(unsafe.Pointer(b)),
+		uintptr(unsafe.Pointer(&async)),
+	)
+	if hr != 0 {
+		return nil, fmt.Errorf("GatherWriterStatus failed: %w", windows.Errno(hr))
+	}
+
+	return async, nil
+}
+
+func (b *IVssBackupComponents) GetWriterStatusCount() (uint32, error) {
+	var count uint32
+
+	hr, _, _ := syscall.SyscallN(
+		b.lpVtbl.GetWriterStatusCount,
+		uintptr(unsafe.Pointer(b)),
+		uintptr(unsafe.Pointer(&count)),
+	)
+	if hr != 0 {
+		return 0, fmt.Errorf("GetWriterStatusCount failed: %w", windows.Errno(hr))
+	}
+
+	return count, nil
+}
+
+func (b *IVssBackupComponents) GetWriterStatus(index uint32) (WriterStatus, error) {
+	var (
+		status WriterStatus
+		name   *uint16
+	)
+
+	hr, _, _ := syscall.SyscallN(
+		b.lpVtbl.GetWriterStatus,
+		uintptr(unsafe.Pointer(b)),
+		uintptr(index),
+		uintptr(unsafe.Pointer(&status.InstanceID)),
+		uintptr(unsafe.Pointer(&status.WriterID)),
+		uintptr(unsafe.Pointer(&name)),
+		uintptr(unsafe.Pointer(&status.State)),
+		uintptr(unsafe.Pointer(&status.FailureResult)),
+	)
+	if hr != 0 {
+		return WriterStatus{}, fmt.Errorf("GetWriterStatus failed: %w", windows.Errno(hr))
+	}
+
+	if name != nil {
+		status.Name = ole.BstrToString(name)
+		ole.SysFreeString((*int16)(unsafe.Pointer(name)))
+	}
+
+	return status, nil
+}
+
+func (b *IVssBackupComponents) FreeWriterStatus() {
+	_, _, _ = syscall.SyscallN(
+		b.lpVtbl.FreeWriterStatus,
+		uintptr(unsafe.Pointer(b)),
+	)
+}
+
+func (b *IVssBackupComponents) Release() {
+	_, _, _ = syscall.SyscallN(
+		b.lpVtbl.Release,
+		uintptr(unsafe.Pointer(b)),
+	)
+}
+
+// Wait waits for the asynchronous operation to finish and releases it.
+func (a *IVssAsync) Wait() error {
+	defer a.release()
+
+	hr, _, _ := syscall.SyscallN(
+		a.lpVtbl.Wait,
+		uintptr(unsafe.Pointer(a)),
+		uintptr(windows.INFINITE),
+	)
+	if hr != 0 {
+		return fmt.Errorf("IVssAsync::Wait failed: %w", windows.Errno(hr))
+	}
+
+	var result uint32
+
+	hr, _, _ = syscall.SyscallN(
+		a.lpVtbl.QueryStatus,
+		uintptr(unsafe.Pointer(a)),
+		uintptr(unsafe.Pointer(&result)),
+		0,
+	)
+	if hr != 0 {
+		return fmt.Errorf("IVssAsync::QueryStatus failed: %w", windows.Errno(hr))
+	}
+
+	if result != VSS_S_ASYNC_FINISHED {
+		return fmt.Errorf("asynchronous operation did not finish: %w", windows.Errno(result))
+	}
+
+	return nil
+}
+
+func (a *IVssAsync) release() {
+	_, _, _ = syscall.SyscallN(
+		a.lpVtbl.Release,
+		uintptr(unsafe.Pointer(a)),
+	)
+}
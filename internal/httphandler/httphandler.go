@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/pkg/collector"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -49,6 +50,11 @@ type MetricsHTTPHandler struct {
 type Options struct {
 	DisableExporterMetrics bool
 	TimeoutMargin          float64
+	// FlagCollector exposes the effective flag values. Optional.
+	FlagCollector prometheus.Collector
+	// RegistryHealthCollector exposes the integrity of the performance counter registry. Optional.
+	// It is only registered if the exporter metrics are enabled.
+	RegistryHealthCollector *pdh.RegistryHealthCollector
 }
 
 func New(logger *slog.Logger, metricCollectors *collector.Collection, options *Options) *MetricsHTTPHandler {
@@ -71,7 +77,12 @@ func New(logger *slog.Logger, metricCollectors *collector.Collection, options *O
 			collectors.NewBuildInfoCollector(),
 			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 			collectors.NewGoCollector(),
+			pdh.NewStatsCollector(),
 		)
+
+		if options.RegistryHealthCollector != nil {
+			handler.exporterMetricsRegistry.MustRegister(options.RegistryHealthCollector)
+		}
 	}
 
 	return handler
@@ -124,6 +135,10 @@ func (c *MetricsHTTPHandler) handlerFactory(logger *slog.Logger, scrapeTimeout t
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(version.NewCollector("windows_exporter"))
 
+	if c.options.FlagCollector != nil {
+		reg.MustRegister(c.options.FlagCollector)
+	}
+
 	collectionHandler, err := c.metricCollectors.NewHandler(scrapeTimeout, c.logger, requestedCollectors)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create collector handler: %w", err)
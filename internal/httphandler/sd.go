@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/sysinfoapi"
+	"golang.org/x/sys/windows/registry"
+)
+
+// sdRefreshInterval is the interval in which the discovered labels are refreshed.
+const sdRefreshInterval = 5 * time.Minute
+
+// SDHandler serves a Prometheus HTTP service discovery target group describing this host.
+// The labels are refreshed at most every sdRefreshInterval, e.g. to follow a node joining or leaving a failover cluster.
+// - https://prometheus.io/docs/prometheus/latest/http_sd/
+type SDHandler struct {
+	logger *slog.Logger
+
+	// discover returns the current target group of this host.
+	discover func() (sdTargetGroup, error)
+
+	mu          sync.Mutex
+	targetGroup *sdTargetGroup // last successfully discovered target group, nil if discovery never succeeded
+	lastRefresh time.Time
+}
+
+type sdTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// Interface guard.
+var _ http.Handler = (*SDHandler)(nil)
+
+// NewSDHandler returns a handler announcing this host on the port of listenAddress.
+// The static labels are added to the discovered labels. The labels are discovered right away,
+// a failed discovery is logged and retried on the next request.
+func NewSDHandler(logger *slog.Logger, listenAddress string, staticLabels map[string]string) (*SDHandler, error) {
+	_, port, err := net.SplitHostPort(listenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse listen address %q: %w", listenAddress, err)
+	}
+
+	h := &SDHandler{
+		logger: logger,
+		discover: func() (sdTargetGroup, error) {
+			return discoverTargetGroup(port, staticLabels)
+		},
+	}
+
+	h.getTargetGroup()
+
+	return h, nil
+}
+
+func (h *SDHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	targetGroup, ok := h.getTargetGroup()
+	if !ok {
+		http.Error(w, "service discovery labels are not available yet", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode([]sdTargetGroup{targetGroup}); err != nil {
+		h.logger.Warn("failed to encode service discovery target group",
+			slog.Any("err", err),
+		)
+
+		http.Error(w, fmt.Sprintf("error encoding JSON: %s", err), http.StatusInternalServerError)
+	}
+}
+
+// getTargetGroup returns the target group of this host. It's refreshed at most every sdRefreshInterval.
+// If the discovery fails, the last successfully discovered target group is returned and the discovery
+// is retried on the next call. It returns false, if the discovery never succeeded.
+func (h *SDHandler) getTargetGroup() (sdTargetGroup, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.targetGroup != nil && time.Since(h.lastRefresh) < sdRefreshInterval {
+		return *h.targetGroup, true
+	}
+
+	targetGroup, err := h.discover()
+	if err != nil {
+		h.logger.Warn("failed to discover service discovery labels",
+			slog.Any("err", err),
+		)
+
+		if h.targetGroup == nil {
+			return sdTargetGroup{}, false
+		}
+
+		return *h.targetGroup, true
+	}
+
+	h.targetGroup = &targetGroup
+	h.lastRefresh = time.Now()
+
+	return targetGroup, true
+}
+
+// discoverTargetGroup returns the target group of this host.
+func discoverTargetGroup(port string, staticLabels map[string]string) (sdTargetGroup, error) {
+	fqdn, err := sysinfoapi.GetComputerName(sysinfoapi.ComputerNameDNSFullyQualified)
+	if err != nil {
+		return sdTargetGroup{}, fmt.Errorf("failed to get computer name: %w", err)
+	}
+
+	hostname, err := sysinfoapi.GetComputerName(sysinfoapi.ComputerNameDNSHostname)
+	if err != nil {
+		return sdTargetGroup{}, fmt.Errorf("failed to get computer name: %w", err)
+	}
+
+	clusterName, err := getClusterName()
+	if err != nil {
+		return sdTargetGroup{}, fmt.Errorf("failed to get cluster name: %w", err)
+	}
+
+	hyperV, err := isHyperVInstalled()
+	if err != nil {
+		return sdTargetGroup{}, fmt.Errorf("failed to detect Hyper-V: %w", err)
+	}
+
+	labels := map[string]string{
+		"__meta_windows_exporter_hostname": hostname,
+		"__meta_windows_exporter_hyperv":   strconv.FormatBool(hyperV),
+	}
+
+	if clusterName != "" {
+		labels["__meta_windows_exporter_cluster_name"] = clusterName
+	}
+
+	maps.Copy(labels, staticLabels)
+
+	return sdTargetGroup{
+		Targets: []string{net.JoinHostPort(fqdn, port)},
+		Labels:  labels,
+	}, nil
+}
+
+// getClusterName returns the name of the failover cluster this host is joined to, or an empty string.
+func getClusterName() (string, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `Cluster`, registry.QUERY_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	defer func(key registry.Key) {
+		_ = key.Close()
+	}(key)
+
+	clusterName, _, err := key.GetStringValue("ClusterName")
+	if errors.Is(err, registry.ErrNotExist) {
+		return "", nil
+	}
+
+	return clusterName, err
+}
+
+// isHyperVInstalled reports whether the Hyper-V Virtual Machine Management service is installed.
+func isHyperVInstalled() (bool, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Services\vmms`, registry.QUERY_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	_ = key.Close()
+
+	return true, nil
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDHandler(t *testing.T) {
+	t.Parallel()
+
+	handler, err := NewSDHandler(slog.New(slog.DiscardHandler), ":9182", map[string]string{
+		"env": "prod",
+		// Static labels override the discovered labels.
+		"__meta_windows_exporter_hyperv": "override",
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sd", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var targetGroups []map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &targetGroups))
+	require.Len(t, targetGroups, 1)
+	require.Len(t, targetGroups[0], 2, "expected only the targets and labels keys")
+
+	var targets []string
+	require.NoError(t, json.Unmarshal(targetGroups[0]["targets"], &targets))
+	require.Len(t, targets, 1)
+	require.True(t, strings.HasSuffix(targets[0], ":9182"), targets[0])
+
+	var labels map[string]string
+	require.NoError(t, json.Unmarshal(targetGroups[0]["labels"], &labels))
+	require.NotEmpty(t, labels["__meta_windows_exporter_hostname"])
+	require.Equal(t, "prod", labels["env"])
+	require.Equal(t, "override", labels["__meta_windows_exporter_hyperv"])
+}
+
+func TestSDHandlerDiscoveryFailure(t *testing.T) {
+	t.Parallel()
+
+	var discoverErr error
+
+	handler := &SDHandler{
+		logger: slog.New(slog.DiscardHandler),
+		discover: func() (sdTargetGroup, error) {
+			if discoverErr != nil {
+				return sdTargetGroup{}, discoverErr
+			}
+
+			return sdTargetGroup{
+				Targets: []string{"host.example.com:9182"},
+				Labels:  map[string]string{"__meta_windows_exporter_cluster_name": "cluster01"},
+			}, nil
+		},
+	}
+
+	serve := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sd", nil))
+
+		return rec
+	}
+
+	// Without a successful discovery, there is nothing to serve.
+	discoverErr = errors.New("registry unavailable")
+
+	require.Equal(t, http.StatusServiceUnavailable, serve().Code)
+
+	discoverErr = nil
+
+	rec := serve()
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "cluster01")
+
+	// A failed refresh serves the last known target group.
+	discoverErr = errors.New("registry unavailable")
+	handler.lastRefresh = time.Time{}
+
+	rec = serve()
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "cluster01")
+}
@@ -22,4 +22,5 @@ import "errors"
 var (
 	ErrNotInitialized    = errors.New("not initialized")
 	ErrInvalidEntityType = errors.New("invalid entity type")
+	ErrInvalidIdentifier = errors.New("invalid WQL identifier")
 )
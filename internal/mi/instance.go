@@ -99,8 +99,9 @@ func (instance *Instance) GetElement(elementName string) (*Element, error) {
 	}
 
 	var (
-		value     uintptr
+		value     miValue
 		valueType ValueType
+		flags     uint32
 	)
 
 	r0, _, _ := syscall.SyscallN(
@@ -109,7 +110,7 @@ func (instance *Instance) GetElement(elementName string) (*Element, error) {
 		uintptr(unsafe.Pointer(elementNameUTF16)),
 		uintptr(unsafe.Pointer(&value)),
 		uintptr(unsafe.Pointer(&valueType)),
-		0,
+		uintptr(unsafe.Pointer(&flags)),
 		0,
 	)
 
@@ -117,10 +118,17 @@ func (instance *Instance) GetElement(elementName string) (*Element, error) {
 		return nil, result
 	}
 
-	return &Element{
-		value:     value,
+	element := &Element{
+		value:     uintptr(value[0]),
 		valueType: valueType,
-	}, nil
+	}
+
+	// Arrays are an MI_Array struct with a pointer to the elements and the number of elements.
+	if valueType&ValueTypeARRAY != 0 && flags&flagNull == 0 {
+		element.arraySize = uint32(value[1])
+	}
+
+	return element, nil
 }
 
 func (instance *Instance) GetElementCount() (uint32, error) {
@@ -158,6 +158,46 @@ func Test_MI_QueryUnmarshal(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// win32BIOS represents the Win32_BIOS WMI class. BIOSVersion and BiosCharacteristics are array properties.
+type win32BIOS struct {
+	BIOSVersion         []string `mi:"BIOSVersion"`
+	BiosCharacteristics []uint16 `mi:"BiosCharacteristics"`
+}
+
+func Test_MI_QueryUnmarshalArray(t *testing.T) {
+	application, err := mi.ApplicationInitialize()
+	require.NoError(t, err)
+	require.NotEmpty(t, application)
+
+	destinationOptions, err := application.NewDestinationOptions()
+	require.NoError(t, err)
+	require.NotEmpty(t, destinationOptions)
+
+	err = destinationOptions.SetTimeout(1 * time.Second)
+	require.NoError(t, err)
+
+	session, err := application.NewSession(destinationOptions)
+	require.NoError(t, err)
+	require.NotEmpty(t, session)
+
+	var bios []win32BIOS
+
+	queryBIOS, err := mi.NewQuery("SELECT BIOSVersion, BiosCharacteristics FROM Win32_BIOS")
+	require.NoError(t, err)
+
+	err = session.QueryUnmarshal(&bios, mi.OperationFlagsStandardRTTI, nil, mi.NamespaceRootCIMv2, mi.QueryDialectWQL, queryBIOS)
+	require.NoError(t, err)
+	require.Len(t, bios, 1)
+	require.NotEmpty(t, bios[0].BIOSVersion)
+	require.NotEmpty(t, bios[0].BIOSVersion[0])
+
+	err = session.Close()
+	require.NoError(t, err)
+
+	err = application.Close()
+	require.NoError(t, err)
+}
+
 func Test_MI_EmptyQuery(t *testing.T) {
 	application, err := mi.ApplicationInitialize()
 	require.NoError(t, err)
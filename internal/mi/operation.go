@@ -202,54 +202,171 @@ func (o *Operation) Unmarshal(dst any) error {
 			break
 		}
 
-		for i := range elemType.NumField() {
-			field := elemValue.Field(i)
+		if err := unmarshalInstance(instance, elemValue); err != nil {
+			return err
+		}
+
+		dv.Set(reflect.Append(dv, elemValue))
+
+		if !moreResults {
+			break
+		}
+	}
+
+	return nil
+}
+
+// unmarshalInstance sets the fields of dst with an `mi` tag to the elements of the instance.
+func unmarshalInstance(instance *Instance, dst reflect.Value) error {
+	elemType := dst.Type()
+
+	for i := range elemType.NumField() {
+		field := dst.Field(i)
+
+		// Check if the field has an `mi` tag
+		miTag := elemType.Field(i).Tag.Get("mi")
+		if miTag == "" {
+			continue
+		}
+
+		element, err := instance.GetElement(miTag)
+		if err != nil {
+			return fmt.Errorf("failed to get element %s: %w", miTag, err)
+		}
+
+		switch element.valueType {
+		case ValueTypeBOOLEAN:
+			field.SetBool(element.value == 1)
+		case ValueTypeUINT8, ValueTypeUINT16, ValueTypeUINT32, ValueTypeUINT64:
+			field.SetUint(uint64(element.value))
+		case ValueTypeSINT8, ValueTypeSINT16, ValueTypeSINT32, ValueTypeSINT64:
+			field.SetInt(int64(element.value))
+		case ValueTypeSTRING:
+			if element.value == 0 {
+				field.SetString("") // Set empty string for nil values
 
-			// Check if the field has an `mi` tag
-			miTag := elemType.Field(i).Tag.Get("mi")
-			if miTag == "" {
 				continue
 			}
 
-			element, err := instance.GetElement(miTag)
-			if err != nil {
-				return fmt.Errorf("failed to get element %s: %w", miTag, err)
+			// Convert uintptr to *uint16 for Windows UTF-16 string
+			// This is safe because element.value comes directly from Windows MI API
+			//goland:noinspection GoVetUnsafePointer
+			stringValue := windows.UTF16PtrToString((*uint16)(unsafe.Pointer(element.value)))
+
+			field.SetString(stringValue)
+		case ValueTypeREAL32, ValueTypeREAL64:
+			field.SetFloat(float64(element.value))
+		case ValueTypeREFERENCE, ValueTypeINSTANCE:
+			// References are unmarshalled into a struct field with the key properties of the referenced instance,
+			// e.g. Win32_ShadowStorage.Volume into a struct with the Win32_Volume.DeviceID.
+			if field.Kind() != reflect.Struct {
+				return fmt.Errorf("element %s: reference requires a struct field", miTag)
 			}
 
-			switch element.valueType {
-			case ValueTypeBOOLEAN:
-				field.SetBool(element.value == 1)
-			case ValueTypeUINT8, ValueTypeUINT16, ValueTypeUINT32, ValueTypeUINT64:
-				field.SetUint(uint64(element.value))
-			case ValueTypeSINT8, ValueTypeSINT16, ValueTypeSINT32, ValueTypeSINT64:
-				field.SetInt(int64(element.value))
-			case ValueTypeSTRING:
-				if element.value == 0 {
-					field.SetString("") // Set empty string for nil values
-
-					continue
-				}
-
-				// Convert uintptr to *uint16 for Windows UTF-16 string
-				// This is safe because element.value comes directly from Windows MI API
-				//goland:noinspection GoVetUnsafePointer
-				stringValue := windows.UTF16PtrToString((*uint16)(unsafe.Pointer(element.value)))
-
-				field.SetString(stringValue)
-			case ValueTypeREAL32, ValueTypeREAL64:
-				field.SetFloat(float64(element.value))
-			default:
-				return fmt.Errorf("unsupported value type: %d", element.valueType)
+			if element.value == 0 {
+				continue
 			}
+
+			//goland:noinspection GoVetUnsafePointer
+			if err := unmarshalInstance((*Instance)(unsafe.Pointer(element.value)), field); err != nil {
+				return fmt.Errorf("element %s: %w", miTag, err)
+			}
+		case ValueTypeBOOLEANA, ValueTypeUINT8A, ValueTypeSINT8A, ValueTypeUINT16A, ValueTypeSINT16A,
+			ValueTypeUINT32A, ValueTypeSINT32A, ValueTypeUINT64A, ValueTypeSINT64A, ValueTypeREAL32A, ValueTypeREAL64A,
+			ValueTypeSTRINGA, ValueTypeREFERENCEA, ValueTypeINSTANCEA:
+			if err := unmarshalArray(element, field); err != nil {
+				return fmt.Errorf("element %s: %w", miTag, err)
+			}
+		default:
+			return fmt.Errorf("unsupported value type: %d", element.valueType)
 		}
+	}
 
-		dv.Set(reflect.Append(dv, elemValue))
+	return nil
+}
 
-		if !moreResults {
-			break
+// unmarshalArray sets the slice field dst to the elements of the array element.
+// Null arrays are unmarshalled into a nil slice. References and instances are unmarshalled into a slice of structs.
+func unmarshalArray(element *Element, dst reflect.Value) error {
+	if dst.Kind() != reflect.Slice {
+		return errors.New("array requires a slice field")
+	}
+
+	values := reflect.MakeSlice(dst.Type(), int(element.arraySize), int(element.arraySize))
+
+	switch element.valueType {
+	case ValueTypeBOOLEANA:
+		for i, v := range arrayElements[uint8](element) {
+			values.Index(i).SetBool(v != 0)
+		}
+	case ValueTypeUINT8A:
+		for i, v := range arrayElements[uint8](element) {
+			values.Index(i).SetUint(uint64(v))
+		}
+	case ValueTypeUINT16A:
+		for i, v := range arrayElements[uint16](element) {
+			values.Index(i).SetUint(uint64(v))
+		}
+	case ValueTypeUINT32A:
+		for i, v := range arrayElements[uint32](element) {
+			values.Index(i).SetUint(uint64(v))
+		}
+	case ValueTypeUINT64A:
+		for i, v := range arrayElements[uint64](element) {
+			values.Index(i).SetUint(v)
+		}
+	case ValueTypeSINT8A:
+		for i, v := range arrayElements[int8](element) {
+			values.Index(i).SetInt(int64(v))
+		}
+	case ValueTypeSINT16A:
+		for i, v := range arrayElements[int16](element) {
+			values.Index(i).SetInt(int64(v))
+		}
+	case ValueTypeSINT32A:
+		for i, v := range arrayElements[int32](element) {
+			values.Index(i).SetInt(int64(v))
+		}
+	case ValueTypeSINT64A:
+		for i, v := range arrayElements[int64](element) {
+			values.Index(i).SetInt(v)
 		}
+	case ValueTypeREAL32A:
+		for i, v := range arrayElements[float32](element) {
+			values.Index(i).SetFloat(float64(v))
+		}
+	case ValueTypeREAL64A:
+		for i, v := range arrayElements[float64](element) {
+			values.Index(i).SetFloat(v)
+		}
+	case ValueTypeSTRINGA:
+		for i, v := range arrayElements[*uint16](element) {
+			values.Index(i).SetString(windows.UTF16PtrToString(v))
+		}
+	case ValueTypeREFERENCEA, ValueTypeINSTANCEA:
+		if dst.Type().Elem().Kind() != reflect.Struct {
+			return errors.New("reference array requires a slice of structs field")
+		}
+
+		for i, v := range arrayElements[*Instance](element) {
+			if v == nil {
+				continue
+			}
+
+			if err := unmarshalInstance(v, values.Index(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported array value type: %d", element.valueType)
 	}
 
+	if element.arraySize == 0 {
+		values = reflect.Zero(dst.Type())
+	}
+
+	dst.Set(values)
+
 	return nil
 }
 
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package mi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QueryBuilder builds WQL SELECT queries. Class and property names are validated,
+// string values are escaped. Conditions are combined with AND.
+type QueryBuilder struct {
+	properties []string
+	class      string
+	conditions []string
+	err        error
+}
+
+// Select starts a new query for the given properties.
+func Select(properties ...string) *QueryBuilder {
+	q := &QueryBuilder{}
+
+	if len(properties) == 0 {
+		q.err = errors.New("no properties selected")
+	}
+
+	for _, property := range properties {
+		if property != "*" {
+			q.validate(property)
+		}
+	}
+
+	q.properties = properties
+
+	return q
+}
+
+// From sets the class of the query.
+func (q *QueryBuilder) From(class string) *QueryBuilder {
+	q.validate(class)
+	q.class = class
+
+	return q
+}
+
+// WhereEqual adds the condition property = 'value'.
+func (q *QueryBuilder) WhereEqual(property, value string) *QueryBuilder {
+	q.validate(property)
+	q.conditions = append(q.conditions, fmt.Sprintf("%s = '%s'", property, escapeString(value)))
+
+	return q
+}
+
+// WhereEqualInt adds the condition property = value.
+func (q *QueryBuilder) WhereEqualInt(property string, value int) *QueryBuilder {
+	q.validate(property)
+	q.conditions = append(q.conditions, fmt.Sprintf("%s = %d", property, value))
+
+	return q
+}
+
+// WhereEqualBool adds the condition property = TRUE or property = FALSE.
+func (q *QueryBuilder) WhereEqualBool(property string, value bool) *QueryBuilder {
+	q.validate(property)
+	q.conditions = append(q.conditions, fmt.Sprintf("%s = %s", property, strings.ToUpper(strconv.FormatBool(value))))
+
+	return q
+}
+
+// WhereIn adds the condition (property = 'value1' OR property = 'value2' ...).
+func (q *QueryBuilder) WhereIn(property string, values ...string) *QueryBuilder {
+	q.validate(property)
+
+	if len(values) == 0 && q.err == nil {
+		q.err = fmt.Errorf("no values for %s", property)
+	}
+
+	alternatives := make([]string, 0, len(values))
+	for _, value := range values {
+		alternatives = append(alternatives, fmt.Sprintf("%s = '%s'", property, escapeString(value)))
+	}
+
+	q.conditions = append(q.conditions, "("+strings.Join(alternatives, " OR ")+")")
+
+	return q
+}
+
+// WhereNotNull adds the condition property IS NOT NULL.
+func (q *QueryBuilder) WhereNotNull(property string) *QueryBuilder {
+	q.validate(property)
+	q.conditions = append(q.conditions, property+" IS NOT NULL")
+
+	return q
+}
+
+// String returns the WQL query or the first validation error.
+func (q *QueryBuilder) String() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+
+	if q.class == "" {
+		return "", errors.New("no class selected")
+	}
+
+	query := "SELECT " + strings.Join(q.properties, ", ") + " FROM " + q.class
+
+	if len(q.conditions) > 0 {
+		query += " WHERE " + strings.Join(q.conditions, " AND ")
+	}
+
+	return query, nil
+}
+
+// Build returns the query for use with Session.Query.
+func (q *QueryBuilder) Build() (Query, error) {
+	query, err := q.String()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewQuery(query)
+}
+
+func (q *QueryBuilder) validate(identifier string) {
+	if q.err != nil {
+		return
+	}
+
+	if !isValidIdentifier(identifier) {
+		q.err = fmt.Errorf("%w: %q", ErrInvalidIdentifier, identifier)
+	}
+}
+
+// isValidIdentifier reports whether s is a valid WQL class or property name.
+func isValidIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// escapeString escapes a value for use in a single-quoted WQL string literal.
+func escapeString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
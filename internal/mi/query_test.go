@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package mi_test
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		query    *mi.QueryBuilder
+		expected string
+		err      error
+	}{
+		{
+			name:     "select",
+			query:    mi.Select("HandleCount").From("Win32_Process"),
+			expected: "SELECT HandleCount FROM Win32_Process",
+		},
+		{
+			name:     "select all",
+			query:    mi.Select("*").From("Win32_ServerFeature"),
+			expected: "SELECT * FROM Win32_ServerFeature",
+		},
+		{
+			name: "where",
+			query: mi.Select("LicenseStatus").From("SoftwareLicensingProduct").
+				WhereEqual("ApplicationID", "55c92734-d682-4d71-983e-d6ec3f16059f").
+				WhereNotNull("PartialProductKey"),
+			expected: "SELECT LicenseStatus FROM SoftwareLicensingProduct WHERE ApplicationID = '55c92734-d682-4d71-983e-d6ec3f16059f' AND PartialProductKey IS NOT NULL",
+		},
+		{
+			name: "where int and bool",
+			query: mi.Select("Name").From("MSFT_NetAdapter").
+				WhereEqualInt("InterfaceType", 6).
+				WhereEqualBool("ConnectorPresent", true),
+			expected: "SELECT Name FROM MSFT_NetAdapter WHERE InterfaceType = 6 AND ConnectorPresent = TRUE",
+		},
+		{
+			name:     "where in",
+			query:    mi.Select("Name", "State").From("Win32_SystemDriver").WhereIn("Name", "vmbus", "storflt"),
+			expected: "SELECT Name, State FROM Win32_SystemDriver WHERE (Name = 'vmbus' OR Name = 'storflt')",
+		},
+		{
+			name:     "escaped value",
+			query:    mi.Select("Name").From("Win32_Service").WhereEqual("PathName", `C:\it's`),
+			expected: `SELECT Name FROM Win32_Service WHERE PathName = 'C:\\it\'s'`,
+		},
+		{
+			name:  "invalid property",
+			query: mi.Select("Name; DROP").From("Win32_Process"),
+			err:   mi.ErrInvalidIdentifier,
+		},
+		{
+			name:  "invalid class",
+			query: mi.Select("Name").From("Win32_Process WHERE 1=1"),
+			err:   mi.ErrInvalidIdentifier,
+		},
+		{
+			name:  "invalid condition property",
+			query: mi.Select("Name").From("Win32_Process").WhereEqual("1Name", "x"),
+			err:   mi.ErrInvalidIdentifier,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := tc.query.String()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, query)
+		})
+	}
+}
@@ -17,7 +17,11 @@
 
 package mi
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+)
 
 type ResultError uint32
 
@@ -55,6 +59,19 @@ func (r ResultError) Error() string {
 	return r.String()
 }
 
+// Unwrap returns the error category from the types package, if any.
+// Invalid namespaces or classes are not categorized here, since they are only expected while building a collector.
+func (r ResultError) Unwrap() error {
+	switch r {
+	case MI_RESULT_ACCESS_DENIED:
+		return types.ErrAccessDenied
+	case MI_RESULT_SERVER_LIMITS_EXCEEDED, MI_RESULT_SERVER_IS_SHUTTING_DOWN:
+		return types.ErrTransient
+	default:
+		return nil
+	}
+}
+
 func (r ResultError) String() string {
 	switch {
 	case errors.Is(r, MI_RESULT_OK):
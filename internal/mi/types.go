@@ -55,6 +55,10 @@ var (
 	NamespaceRootMSCluster         = utils.Must(NewNamespace("root/MSCluster"))
 	NamespaceRootMicrosoftDNS      = utils.Must(NewNamespace("root/MicrosoftDNS"))
 	NamespaceRootStorage           = utils.Must(NewNamespace("root/Microsoft/Windows/Storage"))
+	NamespaceRootStandardCimv2     = utils.Must(NewNamespace("root/StandardCimv2"))
+	NamespaceRootVirtualizationV2  = utils.Must(NewNamespace("root/virtualization/v2"))
+	NamespaceRootWindowsDefender   = utils.Must(NewNamespace("root/Microsoft/Windows/Defender"))
+	NamespaceRootWMI               = utils.Must(NewNamespace("root/WMI"))
 )
 
 type Query *uint16
@@ -64,9 +64,27 @@ const (
 	ValueTypeARRAY ValueType = 16
 )
 
+// flagNull is set in the flags of an element, if the value of the element is null (MI_FLAG_NULL).
+const flagNull = 0x20000000
+
+// miValue is the MI_Value union. Its largest member is MI_Datetime with 36 bytes.
+type miValue [5]uint64
+
 type Element struct {
 	value     uintptr
 	valueType ValueType
+	// arraySize is the number of elements, if valueType is an array type. It is 0 for null arrays.
+	arraySize uint32
+}
+
+// arrayElements returns the elements of an array value. value points to the first of arraySize elements of type T.
+func arrayElements[T any](e *Element) []T {
+	if e.value == 0 || e.arraySize == 0 {
+		return nil
+	}
+
+	//goland:noinspection GoVetUnsafePointer
+	return unsafe.Slice((*T)(unsafe.Pointer(e.value)), e.arraySize)
 }
 
 func (e *Element) GetValue() (any, error) {
@@ -113,8 +131,7 @@ func (e *Element) GetValue() (any, error) {
 			return nil, errors.New("invalid pointer: value is nil")
 		}
 
-		// Assuming array of pointers to UTF-16 strings
-		ptrArray := *(*[]*uint16)(unsafe.Pointer(e.value))
+		ptrArray := arrayElements[*uint16](e)
 		strArray := make([]string, len(ptrArray))
 
 		for i, ptr := range ptrArray {
@@ -31,6 +31,7 @@ type OSVersion struct {
 	MajorVersion uint8
 	MinorVersion uint8
 	Build        uint16
+	ProductType  ProductType
 }
 
 //nolint:gochecknoglobals
@@ -41,6 +42,7 @@ var osv = sync.OnceValue(func() OSVersion {
 		MajorVersion: uint8(v.MajorVersion),
 		MinorVersion: uint8(v.MinorVersion),
 		Build:        uint16(v.BuildNumber),
+		ProductType:  productTypeFromVersionInfo(v.ProductType),
 		// Fill version value so that existing clients don't break
 		Version: v.BuildNumber<<16 | (v.MinorVersion << 8) | v.MajorVersion,
 	}
@@ -58,6 +60,22 @@ func Build() uint16 {
 	return Get().Build
 }
 
+// AtLeast reports whether the build-number on Windows is equal to or greater than the given build-number.
+func AtLeast(build uint16) bool {
+	return Get().AtLeast(build)
+}
+
+// AtLeast reports whether the build-number of the OSVersion is equal to or greater than the given build-number.
+func (osv OSVersion) AtLeast(build uint16) bool {
+	return osv.Build >= build
+}
+
+// Release returns the name of the release of the OSVersion, e.g. "Server 2022" or "23H2".
+// An empty string is returned for unknown build-numbers, e.g. insider builds.
+func (osv OSVersion) Release() string {
+	return releaseName(osv.ProductType, osv.Build)
+}
+
 // String returns the OSVersion formatted as a string. It implements the
 // [fmt.Stringer] interface.
 func (osv OSVersion) String() string {
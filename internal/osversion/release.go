@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package osversion
+
+// ProductType is the product type of a Windows installation.
+type ProductType uint8
+
+const (
+	ProductTypeClient ProductType = iota
+	ProductTypeServer
+)
+
+// Product types as provided by the wProductType member of OSVERSIONINFOEXW.
+// https://learn.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-osversioninfoexw
+const (
+	verNTWorkstation      = 0x1
+	verNTDomainController = 0x2
+	verNTServer           = 0x3
+)
+
+// String returns the ProductType formatted as a string. It implements the
+// [fmt.Stringer] interface.
+func (p ProductType) String() string {
+	if p == ProductTypeServer {
+		return "server"
+	}
+
+	return "client"
+}
+
+// productTypeFromVersionInfo maps the wProductType member of OSVERSIONINFOEXW to a ProductType.
+// Domain controllers are servers.
+func productTypeFromVersionInfo(productType byte) ProductType {
+	switch productType {
+	case verNTDomainController, verNTServer:
+		return ProductTypeServer
+	default:
+		return ProductTypeClient
+	}
+}
+
+//nolint:gochecknoglobals
+var serverReleases = map[uint16]string{
+	LTSC2016:    "Server 2016",
+	V1709:       "Server 1709",
+	V1803:       "Server 1803",
+	LTSC2019:    "Server 2019",
+	V1903:       "Server 1903",
+	V1909:       "Server 1909",
+	V2004:       "Server 2004",
+	V20H2:       "Server 20H2",
+	LTSC2022:    "Server 2022",
+	V23H2Server: "Server 23H2",
+	LTSC2025:    "Server 2025",
+}
+
+//nolint:gochecknoglobals
+var clientReleases = map[uint16]string{
+	V1607:      "1607",
+	V1703:      "1703",
+	V1709:      "1709",
+	V1803:      "1803",
+	V1809:      "1809",
+	V1903:      "1903",
+	V1909:      "1909",
+	V2004:      "2004",
+	V20H2:      "20H2",
+	V21H1:      "21H1",
+	V21H2Win10: "21H2",
+	V22H2Win10: "22H2",
+	V21H2Win11: "21H2",
+	V22H2Win11: "22H2",
+	V23H2Win11: "23H2",
+	V24H2:      "24H2",
+}
+
+// releaseName returns the name of the release for the given product type and build-number.
+func releaseName(productType ProductType, build uint16) string {
+	if productType == ProductTypeServer {
+		return serverReleases[build]
+	}
+
+	return clientReleases[build]
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package osversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		productType ProductType
+		build       uint16
+		expected    string
+	}{
+		{ProductTypeServer, LTSC2016, "Server 2016"},
+		{ProductTypeServer, LTSC2019, "Server 2019"},
+		{ProductTypeServer, LTSC2022, "Server 2022"},
+		{ProductTypeServer, V23H2Server, "Server 23H2"},
+		{ProductTypeServer, LTSC2025, "Server 2025"},
+		{ProductTypeServer, V21H2Win11, ""},
+		{ProductTypeClient, V1607, "1607"},
+		{ProductTypeClient, V1809, "1809"},
+		{ProductTypeClient, V22H2Win10, "22H2"},
+		{ProductTypeClient, V21H2Win11, "21H2"},
+		{ProductTypeClient, V23H2Win11, "23H2"},
+		{ProductTypeClient, V24H2, "24H2"},
+		{ProductTypeClient, LTSC2022, ""},
+		{ProductTypeClient, 27000, ""},
+	} {
+		require.Equal(t, tc.expected, releaseName(tc.productType, tc.build), "%s %d", tc.productType, tc.build)
+	}
+}
+
+func TestProductTypeFromVersionInfo(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ProductTypeClient, productTypeFromVersionInfo(verNTWorkstation))
+	require.Equal(t, ProductTypeServer, productTypeFromVersionInfo(verNTDomainController))
+	require.Equal(t, ProductTypeServer, productTypeFromVersionInfo(verNTServer))
+	require.Equal(t, "server", ProductTypeServer.String())
+	require.Equal(t, "client", ProductTypeClient.String())
+}
+
+func TestOSVersionAtLeast(t *testing.T) {
+	t.Parallel()
+
+	v := OSVersion{Build: LTSC2019}
+
+	require.True(t, v.AtLeast(LTSC2016))
+	require.True(t, v.AtLeast(LTSC2019))
+	require.False(t, v.AtLeast(LTSC2022))
+}
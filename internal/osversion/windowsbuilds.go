@@ -98,4 +98,15 @@ const (
 
 	// V22H2Win11 corresponds to Windows 11 (2022 Update).
 	V22H2Win11 = 22621
+
+	// V23H2Win11 corresponds to Windows 11 (2023 Update).
+	V23H2Win11 = 22631
+
+	// V23H2Server corresponds to Windows Server 23H2 (annual channel).
+	V23H2Server = 25398
+
+	// V24H2 corresponds to Windows Server 2025 (ltsc2025) and Windows 11 (2024 Update).
+	V24H2 = 26100
+	// LTSC2025 (Windows Server 2025) is an alias for [V24H2].
+	LTSC2025 = V24H2
 )
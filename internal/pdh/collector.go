@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"reflect"
 	"slices"
 	"strconv"
@@ -43,6 +44,7 @@ var (
 type CounterValues = map[string]map[string]CounterValue
 
 type Collector struct {
+	name                  string
 	object                string
 	counters              map[string]Counter
 	handle                pdhQueryHandle
@@ -53,10 +55,35 @@ type Collector struct {
 	nameIndexValue        int
 	metricsTypeIndexValue int
 
+	withCounterTypes bool
+	counterTypes     map[string]uint32
+
+	// counterHandles is the number of counters added to the query, tracked for the exporter pdh metrics.
+	counterHandles int
+
 	collectCh chan any
 	errorCh   chan error
 }
 
+// Option configures optional behavior of a Collector.
+type Option func(*Collector)
+
+// WithCounterTypes records the PDH counter type code (e.g. PERF_COUNTER_RAWCOUNT) of each counter.
+// The recorded types are available via Collector.CollectorCounterTypes.
+func WithCounterTypes(enabled bool) Option {
+	return func(c *Collector) {
+		c.withCounterTypes = enabled
+	}
+}
+
+// WithCollectorName sets the name of the windows_exporter collector owning the Collector.
+// It's used as collector label of the windows_exporter_pdh_counters metric.
+func WithCollectorName(name string) Option {
+	return func(c *Collector) {
+		c.name = name
+	}
+}
+
 type Counter struct {
 	Name       string
 	Desc       string
@@ -69,13 +96,17 @@ type Counter struct {
 	FieldIndexSecondValue int
 }
 
-func NewCollector[T any](logger *slog.Logger, resultType CounterType, object string, instances []string) (*Collector, error) {
+func NewCollector[T any](logger *slog.Logger, resultType CounterType, object string, instances []string, opts ...Option) (*Collector, error) {
 	valueType := reflect.TypeFor[T]()
 
-	return NewCollectorWithReflection(logger, resultType, object, instances, valueType)
+	return NewCollectorWithReflection(logger, resultType, object, instances, valueType, opts...)
 }
 
-func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, object string, instances []string, valueType reflect.Type) (*Collector, error) {
+func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, object string, instances []string, valueType reflect.Type, opts ...Option) (*Collector, error) {
+	if resultType != CounterTypeRaw && resultType != CounterTypeFormatted {
+		return nil, fmt.Errorf("invalid result type: %v", resultType)
+	}
+
 	var handle pdhQueryHandle
 
 	if ret := OpenQuery(0, 0, &handle); ret != ErrorSuccess {
@@ -86,10 +117,6 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 		instances = []string{InstanceEmpty}
 	}
 
-	if resultType != CounterTypeRaw && resultType != CounterTypeFormatted {
-		return nil, fmt.Errorf("invalid result type: %v", resultType)
-	}
-
 	collector := &Collector{
 		object:                object,
 		counters:              make(map[string]Counter, valueType.NumField()),
@@ -101,14 +128,23 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 		metricsTypeIndexValue: -1,
 	}
 
+	for _, opt := range opts {
+		opt(collector)
+	}
+
+	if collector.withCounterTypes {
+		collector.counterTypes = make(map[string]uint32, valueType.NumField())
+	}
+
 	errs := make([]error, 0, valueType.NumField())
 
-	if f, ok := valueType.FieldByName("Name"); ok {
-		if f.Type.Kind() == reflect.String {
-			collector.nameIndexValue = f.Index[0]
-		}
+	nameIndexValue, err := nameFieldIndex(valueType)
+	if err != nil {
+		errs = append(errs, err)
 	}
 
+	collector.nameIndexValue = nameIndexValue
+
 	if f, ok := valueType.FieldByName("MetricType"); ok {
 		if f.Type.Kind() == reflect.TypeFor[prometheus.ValueType]().Kind() {
 			collector.metricsTypeIndexValue = f.Index[0]
@@ -166,7 +202,7 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 				if ret == CstatusNoCounter {
 					if minOSBuildTag, ok := f.Tag.Lookup("perfdata_min_build"); ok {
 						if minOSBuild, err := strconv.Atoi(minOSBuildTag); err == nil {
-							if uint16(minOSBuild) > osversion.Build() {
+							if !osversion.AtLeast(uint16(minOSBuild)) {
 								continue
 							}
 						}
@@ -179,6 +215,7 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 			}
 
 			counter.Instances[instance] = counterHandle
+			collector.counterHandles++
 
 			if counter.Type != 0 {
 				continue
@@ -214,6 +251,10 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 			}
 
 			counter.Type = counterInfo.DwType
+			if collector.withCounterTypes {
+				collector.counterTypes[counterName] = counter.Type
+			}
+
 			if val, ok := SupportedCounterTypes[counter.Type]; ok {
 				counter.MetricType = val
 			} else {
@@ -232,14 +273,18 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 		collector.counters[counterName] = counter
 	}
 
-	if err := errors.Join(errs...); err != nil {
-		return collector, fmt.Errorf("failed to initialize collector: %w", err)
-	}
+	if len(collector.counters) == 0 && len(errs) == 0 {
+		CloseQuery(handle)
 
-	if len(collector.counters) == 0 {
 		return nil, errors.New("no counters configured")
 	}
 
+	statsQueryOpened(collector.name, collector.object, collector.counterHandles)
+
+	if err := errors.Join(errs...); err != nil {
+		return collector, fmt.Errorf("failed to initialize collector: %w", err)
+	}
+
 	collector.collectCh = make(chan any)
 	collector.errorCh = make(chan error)
 
@@ -275,6 +320,28 @@ func (c *Collector) Describe() map[string]string {
 	return desc
 }
 
+// Object returns the name of the performance counter object.
+func (c *Collector) Object() string {
+	if c == nil {
+		return ""
+	}
+
+	return c.object
+}
+
+// CollectorCounterTypes returns the PDH counter type code of each counter, keyed by counter name.
+// It returns nil unless the collector was created with WithCounterTypes(true).
+func (c *Collector) CollectorCounterTypes() map[string]uint32 {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return maps.Clone(c.counterTypes)
+}
+
 func (c *Collector) Collect(dst any) error {
 	if c == nil {
 		return ErrPerformanceCounterNotInitialized
@@ -603,7 +670,10 @@ func (c *Collector) Close() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	CloseQuery(c.handle)
+	if c.handle != 0 {
+		CloseQuery(c.handle)
+		statsQueryClosed(c.name, c.object, c.counterHandles)
+	}
 
 	c.handle = 0
 
@@ -619,6 +689,55 @@ func (c *Collector) Close() {
 	c.errorCh = nil
 }
 
+// nameFieldIndex returns the index of the string field which receives the instance name, or -1 if there is none.
+// By default, the instance name is stored in the field Name. A different field can be selected
+// with the struct tag pdh:"name:<field>" on any field of the struct, e.g.
+//
+//	type perfDataCounterValues struct {
+//		_      struct{} `pdh:"name:VMName"`
+//		VMName string
+//	}
+func nameFieldIndex(valueType reflect.Type) (int, error) {
+	var fieldName string
+
+	for _, f := range reflect.VisibleFields(valueType) {
+		tag, ok := f.Tag.Lookup("pdh")
+		if !ok {
+			continue
+		}
+
+		name, ok := strings.CutPrefix(tag, "name:")
+		if !ok || name == "" {
+			return -1, fmt.Errorf("field %s has an invalid pdh tag %q, expected name:<field>", f.Name, tag)
+		}
+
+		if fieldName != "" {
+			return -1, fmt.Errorf("multiple pdh name tags found: %s, %s", fieldName, name)
+		}
+
+		fieldName = name
+	}
+
+	if fieldName == "" {
+		if f, ok := valueType.FieldByName("Name"); ok && f.Type.Kind() == reflect.String {
+			return f.Index[0], nil
+		}
+
+		return -1, nil
+	}
+
+	f, ok := valueType.FieldByName(fieldName)
+	if !ok {
+		return -1, fmt.Errorf("instance name field %s not found", fieldName)
+	}
+
+	if f.Type.Kind() != reflect.String {
+		return -1, fmt.Errorf("instance name field %s must be a string", fieldName)
+	}
+
+	return f.Index[0], nil
+}
+
 func formatCounterPath(object, instance, counterName string) string {
 	var counterPath string
 
@@ -641,3 +760,42 @@ func isKnownCounterDataError(err error) bool {
 		pdhErr.ErrorCode == CstatusNoInstance ||
 		pdhErr.ErrorCode == NoData)
 }
+
+// CounterExists reports whether the counter of the given english counter path, e.g. \Hyper-V Hypervisor\Logical Processors,
+// is registered on the local machine.
+func CounterExists(counterPath string) (bool, error) {
+	var handle pdhQueryHandle
+
+	if ret := OpenQuery(0, 0, &handle); ret != ErrorSuccess {
+		return false, NewPdhError(ret)
+	}
+
+	defer CloseQuery(handle)
+
+	var counterHandle pdhCounterHandle
+
+	switch ret := AddEnglishCounter(handle, counterPath, 0, &counterHandle); ret {
+	case ErrorSuccess:
+		return true, nil
+	case CstatusNoObject, CstatusNoCounter:
+		return false, nil
+	default:
+		return false, NewPdhError(ret)
+	}
+}
+
+// InstancesFromPattern returns the instances to pass to NewCollector for an instance pattern in the PDH wildcard syntax,
+// e.g. "C:" or "Harddisk*". The instances are selected by PDH, before any data is collected.
+// An empty pattern selects all instances.
+func InstancesFromPattern(pattern string) ([]string, error) {
+	if pattern == "" {
+		return InstancesAll, nil
+	}
+
+	// Parentheses and backslashes would break the counter path \object(instance)\counter.
+	if strings.ContainsAny(pattern, `()\`) {
+		return nil, fmt.Errorf("invalid instance pattern %q: must not contain parentheses or backslashes", pattern)
+	}
+
+	return []string{pattern}, nil
+}
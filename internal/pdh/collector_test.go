@@ -19,10 +19,12 @@ package pdh_test
 
 import (
 	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 )
 
@@ -71,3 +73,131 @@ func TestCollector(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectorWithCounterTypes(t *testing.T) {
+	t.Parallel()
+
+	performanceData, err := pdh.NewCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll, pdh.WithCounterTypes(true))
+	require.NoError(t, err)
+
+	t.Cleanup(performanceData.Close)
+
+	require.Equal(t, map[string]uint32{"Thread Count": pdh.PERF_COUNTER_RAWCOUNT}, performanceData.CollectorCounterTypes())
+
+	performanceData, err = pdh.NewCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	t.Cleanup(performanceData.Close)
+
+	require.Nil(t, performanceData.CollectorCounterTypes())
+}
+
+type processNamed struct {
+	_           struct{} `pdh:"name:ProcessName"`
+	ProcessName string
+	ThreadCount float64 `perfdata:"Thread Count"`
+}
+
+type processInvalidName struct {
+	_           struct{} `pdh:"name:ThreadCount"`
+	ThreadCount float64  `perfdata:"Thread Count"`
+}
+
+func TestCollectorWithNameTag(t *testing.T) {
+	t.Parallel()
+
+	performanceData, err := pdh.NewCollector[processNamed](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	t.Cleanup(performanceData.Close)
+
+	var data []processNamed
+
+	require.NoError(t, performanceData.Collect(&data))
+	require.NotEmpty(t, data)
+
+	for _, instance := range data {
+		require.NotEmpty(t, instance.ProcessName)
+	}
+
+	performanceData, err = pdh.NewCollector[processInvalidName](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.ErrorContains(t, err, "instance name field ThreadCount must be a string")
+
+	t.Cleanup(performanceData.Close)
+}
+
+func TestCollectorWithInstancePattern(t *testing.T) {
+	t.Parallel()
+
+	instances, err := pdh.InstancesFromPattern("")
+	require.NoError(t, err)
+	require.Equal(t, pdh.InstancesAll, instances)
+
+	_, err = pdh.InstancesFromPattern(`C:)\Free Megabytes`)
+	require.Error(t, err)
+
+	instances, err = pdh.InstancesFromPattern("svc*")
+	require.NoError(t, err)
+
+	performanceData, err := pdh.NewCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", instances)
+	require.NoError(t, err)
+
+	t.Cleanup(performanceData.Close)
+
+	var data []process
+
+	require.NoError(t, performanceData.Collect(&data))
+	require.NotEmpty(t, data)
+
+	for _, instance := range data {
+		require.True(t, strings.HasPrefix(instance.Name, "svc"), instance.Name)
+	}
+}
+
+func TestCollectorStats(t *testing.T) {
+	t.Parallel()
+
+	counters := func() float64 {
+		t.Helper()
+
+		registry := prometheus.NewRegistry()
+		require.NoError(t, registry.Register(pdh.NewStatsCollector()))
+
+		metricFamilies, err := registry.Gather()
+		require.NoError(t, err)
+
+		for _, metricFamily := range metricFamilies {
+			if metricFamily.GetName() != "windows_exporter_pdh_counters" {
+				continue
+			}
+
+			for _, metric := range metricFamily.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == "collector" && label.GetValue() == "stats_test" {
+						return metric.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+
+		return 0
+	}
+
+	performanceData, err := pdh.NewCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll, pdh.WithCollectorName("stats_test"))
+	require.NoError(t, err)
+
+	expected := counters()
+	require.InDelta(t, 1, expected, 0)
+
+	var data []process
+
+	for range 100 {
+		require.NoError(t, performanceData.Collect(&data))
+	}
+
+	require.InDelta(t, expected, counters(), 0)
+
+	performanceData.Close()
+
+	require.InDelta(t, 0, counters(), 0)
+}
@@ -17,7 +17,11 @@
 
 package pdh
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+)
 
 var (
 	ErrNoData                           = NewPdhError(NoData)
@@ -47,6 +51,19 @@ func (m *Error) Error() string {
 	return m.errorText
 }
 
+// Unwrap returns the error category from the types package, if any.
+// Missing objects or counters are not categorized here, since they are only expected while building a collector.
+func (m *Error) Unwrap() error {
+	switch m.ErrorCode {
+	case AccessDenied:
+		return types.ErrAccessDenied
+	case CstatusNoMachine, CannotConnectMachine, CannotConnectWmiServer, Retry, AsyncQueryTimeout:
+		return types.ErrTransient
+	default:
+		return nil
+	}
+}
+
 func NewPdhError(code uint32) error {
 	return &Error{
 		ErrorCode: code,
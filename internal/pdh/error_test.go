@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorUnwrap(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		code     uint32
+		expected error
+	}{
+		{code: pdh.AccessDenied, expected: types.ErrAccessDenied},
+		{code: pdh.CannotConnectMachine, expected: types.ErrTransient},
+	} {
+		t.Run(fmt.Sprintf("0x%X", tc.code), func(t *testing.T) {
+			t.Parallel()
+
+			err := fmt.Errorf("failed to collect metrics: %w", pdh.NewPdhError(tc.code))
+
+			require.ErrorIs(t, err, tc.expected)
+			require.ErrorIs(t, err, pdh.NewPdhError(tc.code))
+		})
+	}
+
+	// Missing objects are only categorized by the collection, if the collector failed to build.
+	require.NotErrorIs(t, pdh.NewPdhError(pdh.CstatusNoObject), types.ErrCounterSetMissing)
+	require.NotErrorIs(t, pdh.ErrNoData, types.ErrCounterSetMissing)
+}
@@ -265,6 +265,7 @@ const (
 	FmtNocap100        = 0x00008000 // can be OR-ed: do not cap values > 100.
 	PerfDetailCostly   = 0x00010000
 	PerfDetailStandard = 0x0000FFFF
+	PerfDetailWizard   = 400 // Counters, which are used by the majority of users.
 )
 
 type (
@@ -290,6 +291,8 @@ var (
 	pdhGetRawCounterValue        = libPdhDll.NewProc("PdhGetRawCounterValue")
 	pdhGetRawCounterArrayW       = libPdhDll.NewProc("PdhGetRawCounterArrayW")
 	pdhPdhGetCounterTimeBase     = libPdhDll.NewProc("PdhGetCounterTimeBase")
+	pdhEnumObjectsW              = libPdhDll.NewProc("PdhEnumObjectsW")
+	pdhLookupPerfNameByIndexW    = libPdhDll.NewProc("PdhLookupPerfNameByIndexW")
 )
 
 // AddCounter adds the specified counter to the query. This is the internationalized version. Preferably, use the
@@ -537,6 +540,38 @@ func ExpandWildCardPath(szWildCardPath string, mszExpandedPathList *uint16, pcch
 	return uint32(ret)
 }
 
+// EnumObjects returns a list of the performance objects available on the local computer.
+// mszObjectList receives the object names as MULTI_SZ list. If mszObjectList is nil, pcchBufferSize receives the
+// required buffer size in characters and MoreData is returned. If bRefresh is true, the object list is refreshed.
+func EnumObjects(mszObjectList *uint16, pcchBufferSize *uint32, dwDetailLevel uint32, bRefresh bool) uint32 {
+	var refresh uintptr
+	if bRefresh {
+		refresh = 1
+	}
+
+	ret, _, _ := pdhEnumObjectsW.Call(
+		0, // real-time data
+		0, // local computer
+		uintptr(unsafe.Pointer(mszObjectList)),
+		uintptr(unsafe.Pointer(pcchBufferSize)),
+		uintptr(dwDetailLevel),
+		refresh)
+
+	return uint32(ret)
+}
+
+// LookupPerfNameByIndex returns the localized name of the performance object or counter with the given index
+// of the counter name table on the local computer.
+func LookupPerfNameByIndex(dwNameIndex uint32, szNameBuffer *uint16, pcchNameBufferSize *uint32) uint32 {
+	ret, _, _ := pdhLookupPerfNameByIndexW.Call(
+		0, // local computer
+		uintptr(dwNameIndex),
+		uintptr(unsafe.Pointer(szNameBuffer)),
+		uintptr(unsafe.Pointer(pcchNameBufferSize)))
+
+	return uint32(ret)
+}
+
 // ValidatePath validates a path. Will return ErrorSuccess when ok, or PdhCstatusBadCountername when the path is erroneous.
 func ValidatePath(path string) uint32 {
 	ptxt, _ := windows.UTF16PtrFromString(path)
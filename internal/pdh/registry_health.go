@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/win32"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+// Reasons of a failed integrity check of the performance counter registry.
+const (
+	RegistryReasonEnumObjectsFailed = "enum_objects_failed"
+	RegistryReasonNoObjects         = "no_objects"
+	RegistryReasonMissingBaseIndex  = "missing_base_index"
+)
+
+// registryHealthCheckInterval is the interval between two integrity checks of RegistryHealthCollector.
+const registryHealthCheckInterval = 5 * time.Minute
+
+// registryBaseIndexes are indexes of the counter name table which exist on every Windows installation,
+// 2 (System) and 238 (Processor). They are missing if the counter name table is corrupted.
+//
+//nolint:gochecknoglobals
+var registryBaseIndexes = []uint32{2, 238}
+
+// CheckRegistry checks the integrity of the performance counter registry. It returns the reason
+// and the error of the first failed check, or an empty reason if the registry is healthy.
+// A corrupted registry can be rebuilt by running "lodctr /R" as administrator.
+func CheckRegistry() (string, error) {
+	var size uint32
+
+	ret := EnumObjects(nil, &size, PerfDetailWizard, true)
+
+	switch ret {
+	case MoreData:
+	case ErrorSuccess:
+		return RegistryReasonNoObjects, errors.New("PdhEnumObjects returned no performance objects")
+	default:
+		return RegistryReasonEnumObjectsFailed, fmt.Errorf("PdhEnumObjects failed: %w", NewPdhError(ret))
+	}
+
+	buf := make([]uint16, size)
+
+	if ret := EnumObjects(&buf[0], &size, PerfDetailWizard, false); ret != ErrorSuccess {
+		return RegistryReasonEnumObjectsFailed, fmt.Errorf("PdhEnumObjects failed: %w", NewPdhError(ret))
+	}
+
+	if len(win32.ParseMultiSz(buf[:size])) == 0 {
+		return RegistryReasonNoObjects, errors.New("PdhEnumObjects returned no performance objects")
+	}
+
+	for _, index := range registryBaseIndexes {
+		name := make([]uint16, 1024)
+		size = uint32(len(name))
+
+		if ret := LookupPerfNameByIndex(index, &name[0], &size); ret != ErrorSuccess {
+			return RegistryReasonMissingBaseIndex, fmt.Errorf("PdhLookupPerfNameByIndex(%d) failed: %w", index, NewPdhError(ret))
+		}
+
+		if windows.UTF16ToString(name) == "" {
+			return RegistryReasonMissingBaseIndex, fmt.Errorf("PdhLookupPerfNameByIndex(%d) returned an empty name", index)
+		}
+	}
+
+	return "", nil
+}
+
+// RegistryHealthCollector exposes the result of the integrity check of the performance counter registry.
+// The check refreshes the counter name table and can take several seconds, so it runs in the background
+// every registryHealthCheckInterval and Collect exposes the result of the last check.
+type RegistryHealthCollector struct {
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	checked bool
+	reason  string
+
+	healthy *prometheus.Desc
+}
+
+// NewRegistryHealthCollector returns a RegistryHealthCollector and starts the background checks,
+// which run until ctx is cancelled. No metric is exposed until the first check has finished.
+// If the first check fails, a warning is logged, since a corrupted registry lets the PDH based
+// collectors fail with cryptic errors.
+func NewRegistryHealthCollector(ctx context.Context, logger *slog.Logger) *RegistryHealthCollector {
+	c := &RegistryHealthCollector{
+		logger: logger,
+		healthy: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "perfcounter_registry_healthy"),
+			"1 if the integrity check of the performance counter registry succeeded, 0 otherwise. reason contains the failed check.",
+			[]string{"reason"},
+			nil,
+		),
+	}
+
+	go c.run(ctx)
+
+	return c
+}
+
+func (c *RegistryHealthCollector) run(ctx context.Context) {
+	ticker := time.NewTicker(registryHealthCheckInterval)
+	defer ticker.Stop()
+
+	for first := true; ; first = false {
+		reason, err := CheckRegistry()
+		if first && err != nil {
+			c.logger.LogAttrs(ctx, slog.LevelWarn, "performance counter registry seems to be corrupted. Run 'lodctr /R' as administrator to rebuild it",
+				slog.String("reason", reason),
+				slog.Any("err", err),
+			)
+		}
+
+		c.mu.Lock()
+		c.checked = true
+		c.reason = reason
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *RegistryHealthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.healthy
+}
+
+func (c *RegistryHealthCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.checked {
+		return
+	}
+
+	healthy := 0.0
+	if c.reason == "" {
+		healthy = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.healthy,
+		prometheus.GaugeValue,
+		healthy,
+		c.reason,
+	)
+}
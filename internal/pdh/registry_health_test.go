@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRegistry(t *testing.T) {
+	t.Parallel()
+
+	reason, err := pdh.CheckRegistry()
+	require.NoError(t, err)
+	require.Empty(t, reason)
+}
+
+func TestRegistryHealthCollector(t *testing.T) {
+	t.Parallel()
+
+	c := pdh.NewRegistryHealthCollector(t.Context(), slog.New(slog.DiscardHandler))
+
+	// The first check runs in the background, Collect doesn't wait for it.
+	require.Eventually(t, func() bool {
+		return testutil.CollectAndCount(c) == 1
+	}, time.Minute, 100*time.Millisecond)
+}
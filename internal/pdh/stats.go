@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"sync"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type statsKey struct {
+	collector string
+	object    string
+}
+
+// stats tracks the PDH queries and counters opened by all Collector instances.
+// It's used to verify that counters are not leaked or re-added on each scrape.
+//
+//nolint:gochecknoglobals
+var stats = struct {
+	mu       sync.Mutex
+	queries  int
+	counters map[statsKey]int
+}{
+	counters: make(map[statsKey]int),
+}
+
+func statsQueryOpened(collector, object string, counters int) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.queries++
+	stats.counters[statsKey{collector, object}] += counters
+}
+
+func statsQueryClosed(collector, object string, counters int) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.queries--
+
+	key := statsKey{collector, object}
+
+	stats.counters[key] -= counters
+	if stats.counters[key] <= 0 {
+		delete(stats.counters, key)
+	}
+}
+
+// StatsCollector exposes the number of open PDH queries and counters.
+type StatsCollector struct {
+	queries  *prometheus.Desc
+	counters *prometheus.Desc
+}
+
+func NewStatsCollector() *StatsCollector {
+	return &StatsCollector{
+		queries: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "pdh_queries"),
+			"Number of open PDH queries.",
+			nil,
+			nil,
+		),
+		counters: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "pdh_counters"),
+			"Number of PDH counters added to open queries.",
+			[]string{"collector", "object"},
+			nil,
+		),
+	}
+}
+
+func (s *StatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.queries
+	ch <- s.counters
+}
+
+func (s *StatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(
+		s.queries,
+		prometheus.GaugeValue,
+		float64(stats.queries),
+	)
+
+	for key, counters := range stats.counters {
+		ch <- prometheus.MustNewConstMetric(
+			s.counters,
+			prometheus.GaugeValue,
+			float64(counters),
+			key.collector, key.object,
+		)
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package bdf enforces the metric naming convention of the exporter.
+// All metrics are named windows_<subsystem>_<name> in lower snake case.
+// The convention is checked at runtime, when a collector creates its descriptors in Build, not at compile time.
+package bdf
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+//nolint:gochecknoglobals
+var (
+	nameRegExp      = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	labelNameRegExp = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+)
+
+// NewDesc returns a new *prometheus.Desc for the metric windows_<subsystem>_<name>.
+// Like regexp.MustCompile, it panics if the metric does not follow the naming convention.
+// Since every collector builds its descriptors in Build, a violation is caught by the collector tests.
+func NewDesc(subsystem, name, help string, labelNames []string) *prometheus.Desc {
+	if err := Validate(subsystem, name, help, labelNames); err != nil {
+		panic(err)
+	}
+
+	return prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, subsystem, name),
+		help,
+		labelNames,
+		nil,
+	)
+}
+
+// Validate checks the metric windows_<subsystem>_<name> against the naming convention.
+func Validate(subsystem, name, help string, labelNames []string) error {
+	fqName := prometheus.BuildFQName(types.Namespace, subsystem, name)
+
+	if !nameRegExp.MatchString(subsystem) {
+		return fmt.Errorf("metric %s: subsystem %q is not in lower snake case", fqName, subsystem)
+	}
+
+	if !nameRegExp.MatchString(name) {
+		return fmt.Errorf("metric %s: name %q is not in lower snake case", fqName, name)
+	}
+
+	if strings.HasPrefix(name, subsystem+"_") {
+		return fmt.Errorf("metric %s: name %q repeats the subsystem", fqName, name)
+	}
+
+	if strings.TrimSpace(help) == "" {
+		return fmt.Errorf("metric %s: help is empty", fqName)
+	}
+
+	seen := make(map[string]struct{}, len(labelNames))
+
+	for _, labelName := range labelNames {
+		if !labelNameRegExp.MatchString(labelName) {
+			return fmt.Errorf("metric %s: label %q is not in lower snake case", fqName, labelName)
+		}
+
+		if _, ok := seen[labelName]; ok {
+			return fmt.Errorf("metric %s: duplicate label %q", fqName, labelName)
+		}
+
+		seen[labelName] = struct{}{}
+	}
+
+	return nil
+}
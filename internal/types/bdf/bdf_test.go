@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package bdf_test
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/types/bdf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		subsystem  string
+		metric     string
+		help       string
+		labelNames []string
+		valid      bool
+	}{
+		{name: "valid", subsystem: "hyperv", metric: "virtual_storage_device_bytes_read", help: "help", labelNames: []string{"device"}, valid: true},
+		{name: "valid without labels", subsystem: "os", metric: "install_time_timestamp", help: "help", valid: true},
+		{name: "camel case name", subsystem: "os", metric: "installTime", help: "help"},
+		{name: "trailing underscore", subsystem: "os", metric: "install_time_", help: "help"},
+		{name: "double underscore", subsystem: "os", metric: "install__time", help: "help"},
+		{name: "upper case subsystem", subsystem: "OS", metric: "install_time", help: "help"},
+		{name: "name repeats subsystem", subsystem: "os", metric: "os_install_time", help: "help"},
+		{name: "empty help", subsystem: "os", metric: "install_time", help: " "},
+		{name: "reserved label", subsystem: "os", metric: "info", help: "help", labelNames: []string{"__name__"}},
+		{name: "duplicate label", subsystem: "os", metric: "info", help: "help", labelNames: []string{"version", "version"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := bdf.Validate(tc.subsystem, tc.metric, tc.help, tc.labelNames)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNewDescPanics(t *testing.T) {
+	t.Parallel()
+
+	require.Panics(t, func() {
+		bdf.NewDesc("os", "InstallTime", "help", nil)
+	})
+
+	require.NotPanics(t, func() {
+		bdf.NewDesc("os", "install_time_timestamp", "help", nil)
+	})
+}
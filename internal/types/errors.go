@@ -23,4 +23,12 @@ var (
 	ErrCollectorNotInitialized = errors.New("collector not initialized")
 	ErrNoData                  = errors.New("no data")
 	ErrNoDataUnexpected        = errors.New("no data")
+
+	// ErrCounterSetMissing indicates that a performance counter set or WMI class is not available on this host.
+	// Collectors failing with this error are degraded quietly.
+	ErrCounterSetMissing = errors.New("counter set missing")
+	// ErrAccessDenied indicates that the exporter lacks the permissions to read the data.
+	ErrAccessDenied = errors.New("access denied")
+	// ErrTransient indicates a temporary failure which may succeed on the next scrape.
+	ErrTransient = errors.New("transient failure")
 )
@@ -36,6 +36,8 @@ import (
 type collectorStatus struct {
 	name       string
 	statusCode collectorStatusCode
+	// reason is the error category of a failed or degraded collector. Empty on success.
+	reason string
 }
 
 type collectorStatusCode int
@@ -63,9 +65,12 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 		go func(name string, metricsCollector Collector) {
 			defer wg.Done()
 
+			statusCode, reason := c.collectCollector(ch, logger, name, metricsCollector, maxScrapeDuration)
+
 			collectorStatusCh <- collectorStatus{
 				name:       name,
-				statusCode: c.collectCollector(ch, logger, name, metricsCollector, maxScrapeDuration),
+				statusCode: statusCode,
+				reason:     reason,
 			}
 		}(name, metricsCollector)
 	}
@@ -93,6 +98,16 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 			status.name,
 		)
 
+		if status.reason != "" {
+			ch <- prometheus.MustNewConstMetric(
+				c.collectorScrapeErrorReasonDesc,
+				prometheus.GaugeValue,
+				1,
+				status.name,
+				status.reason,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
 			c.collectorScrapeTimeoutDesc,
 			prometheus.GaugeValue,
@@ -108,7 +123,7 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 	)
 }
 
-func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.Logger, name string, collector Collector, maxScrapeDuration time.Duration) collectorStatusCode {
+func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.Logger, name string, collector Collector, maxScrapeDuration time.Duration) (collectorStatusCode, string) {
 	var (
 		err        error
 		numMetrics int
@@ -204,25 +219,39 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 			}
 		}()
 
-		return pending
+		return pending, "timeout"
 	}
 
 	slogAttrs := make([]slog.Attr, 0)
 
 	result := "succeeded"
 
+	if _, ok := c.counterSetMissing[name]; ok && err != nil {
+		// Only collectors which already failed to build due to a missing counter set are degraded quietly.
+		err = fmt.Errorf("%w: %w", types.ErrCounterSetMissing, err)
+	}
+
+	reason := errorReason(err)
+
 	if err != nil {
-		if !errors.Is(err, pdh.ErrNoData) && !errors.Is(err, types.ErrNoData) && !errors.Is(err, windows.EPT_S_NOT_REGISTERED) {
+		if !errors.Is(err, pdh.ErrNoData) && !errors.Is(err, types.ErrNoData) && !errors.Is(err, types.ErrCounterSetMissing) &&
+			!errors.Is(err, windows.EPT_S_NOT_REGISTERED) {
 			if errors.Is(err, pdh.ErrPerformanceCounterNotInitialized) {
 				err = fmt.Errorf("%w. Check application logs from initialization pharse for more information", err)
 			}
 
-			logger.LogAttrs(ctx, slog.LevelWarn,
+			level := slog.LevelWarn
+			if errors.Is(err, types.ErrAccessDenied) {
+				level = slog.LevelError
+			}
+
+			logger.LogAttrs(ctx, level,
 				fmt.Sprintf("collector %s failed after %s, resulting in %d metrics", name, duration, numMetrics),
 				slog.Any("err", err),
+				slog.String("reason", reason),
 			)
 
-			return failed
+			return failed, reason
 		}
 
 		slogAttrs = append(slogAttrs, slog.Any("err", err))
@@ -236,5 +265,21 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 		slogAttrs...,
 	)
 
-	return success
+	return success, reason
+}
+
+// errorReason returns the value of the reason label of the collector_success metric for the given error.
+func errorReason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, types.ErrCounterSetMissing):
+		return "counter_set_missing"
+	case errors.Is(err, types.ErrAccessDenied):
+		return "access_denied"
+	case errors.Is(err, types.ErrTransient):
+		return "transient"
+	default:
+		return "error"
+	}
 }
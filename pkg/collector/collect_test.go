@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorReason(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "nil", err: nil, expected: ""},
+		{name: "counter set missing", err: fmt.Errorf("%w: %w", types.ErrCounterSetMissing, pdh.NewPdhError(pdh.CstatusNoObject)), expected: "counter_set_missing"},
+		{name: "pdh access denied", err: fmt.Errorf("failed to collect metrics: %w", pdh.NewPdhError(pdh.AccessDenied)), expected: "access_denied"},
+		{name: "mi access denied", err: fmt.Errorf("WMI query failed: %w", mi.MI_RESULT_ACCESS_DENIED), expected: "access_denied"},
+		{name: "pdh transient", err: fmt.Errorf("failed to collect metrics: %w", pdh.NewPdhError(pdh.CannotConnectMachine)), expected: "transient"},
+		{name: "mi transient", err: fmt.Errorf("WMI query failed: %w", mi.MI_RESULT_SERVER_IS_SHUTTING_DOWN), expected: "transient"},
+		{name: "pdh missing object at runtime", err: fmt.Errorf("failed to collect metrics: %w", pdh.NewPdhError(pdh.CstatusNoObject)), expected: "error"},
+		{name: "mi invalid class at runtime", err: fmt.Errorf("WMI query failed: %w", mi.MI_RESULT_INVALID_CLASS), expected: "error"},
+		{name: "mi failed", err: fmt.Errorf("WMI query failed: %w", mi.MI_RESULT_FAILED), expected: "error"},
+		{name: "other", err: errors.New("something went wrong"), expected: "error"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, errorReason(tc.err))
+		})
+	}
+}
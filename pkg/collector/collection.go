@@ -45,6 +45,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/gpu"
 	"github.com/prometheus-community/windows_exporter/internal/collector/hyperv"
 	"github.com/prometheus-community/windows_exporter/internal/collector/iis"
+	"github.com/prometheus-community/windows_exporter/internal/collector/ipsec"
 	"github.com/prometheus-community/windows_exporter/internal/collector/license"
 	"github.com/prometheus-community/windows_exporter/internal/collector/logical_disk"
 	"github.com/prometheus-community/windows_exporter/internal/collector/memory"
@@ -75,6 +76,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/udp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/update"
 	"github.com/prometheus-community/windows_exporter/internal/collector/vmware"
+	"github.com/prometheus-community/windows_exporter/internal/collector/vss"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -115,6 +117,7 @@ func NewWithConfig(config Config) *Collection {
 	collectors[gpu.Name] = gpu.New(&config.GPU)
 	collectors[hyperv.Name] = hyperv.New(&config.HyperV)
 	collectors[iis.Name] = iis.New(&config.IIS)
+	collectors[ipsec.Name] = ipsec.New(&config.IPsec)
 	collectors[license.Name] = license.New(&config.License)
 	collectors[logical_disk.Name] = logical_disk.New(&config.LogicalDisk)
 	collectors[memory.Name] = memory.New(&config.Memory)
@@ -145,6 +148,7 @@ func NewWithConfig(config Config) *Collection {
 	collectors[udp.Name] = udp.New(&config.UDP)
 	collectors[update.Name] = update.New(&config.Update)
 	collectors[vmware.Name] = vmware.New(&config.Vmware)
+	collectors[vss.Name] = vss.New(&config.Vss)
 
 	return New(collectors)
 }
@@ -172,6 +176,12 @@ func New(collectors Map) *Collection {
 			[]string{"collector"},
 			nil,
 		),
+		collectorScrapeErrorReasonDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_error_reason"),
+			"windows_exporter: The error category of a failed, timed out or degraded collector. Not exposed for successful collections.",
+			[]string{"collector", "reason"},
+			nil,
+		),
 		collectorScrapeTimeoutDesc: prometheus.NewDesc(
 			prometheus.BuildFQName(types.Namespace, "exporter", "collector_timeout"),
 			"windows_exporter: Whether the collector timed out.",
@@ -207,6 +217,22 @@ func (c *Collection) Disable(disabledCollectors []string) {
 	}
 }
 
+// Validate checks the configuration of all enabled collectors without accessing PDH or WMI.
+// The result contains an entry for each enabled collector. Collectors without a validator are reported as valid.
+func (c *Collection) Validate() map[string]error {
+	result := make(map[string]error, len(c.collectors))
+
+	for name, collector := range c.collectors {
+		if validator, ok := collector.(Validator); ok {
+			result[name] = validator.Validate()
+		} else {
+			result[name] = nil
+		}
+	}
+
+	return result
+}
+
 // Build To be called by the exporter for collector initialization.
 // Instead, fail fast, it will try to build all collectors and return all errors.
 // errors are joined with errors.Join.
@@ -223,11 +249,23 @@ func (c *Collection) Build(ctx context.Context, logger *slog.Logger) error {
 
 	errCh := make(chan error, len(c.collectors))
 
-	for _, collector := range c.collectors {
+	var counterSetMissingMu sync.Mutex
+
+	c.counterSetMissing = make(map[string]struct{})
+
+	for name, collector := range c.collectors {
 		go func() {
 			defer wg.Done()
 
 			if err := collector.Build(logger, c.miSession); err != nil {
+				if isCounterSetMissing(err) {
+					counterSetMissingMu.Lock()
+					c.counterSetMissing[name] = struct{}{}
+					counterSetMissingMu.Unlock()
+
+					err = fmt.Errorf("%w: %w", types.ErrCounterSetMissing, err)
+				}
+
 				errCh <- fmt.Errorf("error build collector %s: %w", collector.GetName(), err)
 			}
 		}()
@@ -242,9 +280,7 @@ func (c *Collection) Build(ctx context.Context, logger *slog.Logger) error {
 	for err := range errCh {
 		if errors.Is(err, pdh.ErrNoData) ||
 			errors.Is(err, registry.ErrNotExist) ||
-			errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) ||
-			errors.Is(err, pdh.NewPdhError(pdh.CstatusNoCounter)) ||
-			errors.Is(err, mi.MI_RESULT_INVALID_NAMESPACE) {
+			errors.Is(err, types.ErrCounterSetMissing) {
 			logger.LogAttrs(ctx, slog.LevelWarn, "couldn't initialize collector", slog.Any("err", err))
 
 			continue
@@ -256,6 +292,15 @@ func (c *Collection) Build(ctx context.Context, logger *slog.Logger) error {
 	return errors.Join(errs...)
 }
 
+// isCounterSetMissing reports whether the Build error of a collector indicates
+// that the performance counter set or WMI class is not available on this host.
+func isCounterSetMissing(err error) bool {
+	return errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) ||
+		errors.Is(err, pdh.NewPdhError(pdh.CstatusNoCounter)) ||
+		errors.Is(err, mi.MI_RESULT_INVALID_NAMESPACE) ||
+		errors.Is(err, mi.MI_RESULT_INVALID_CLASS)
+}
+
 // Close To be called by the exporter for collector cleanup.
 func (c *Collection) Close() error {
 	errs := make([]error, 0, len(c.collectors))
@@ -35,6 +35,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/gpu"
 	"github.com/prometheus-community/windows_exporter/internal/collector/hyperv"
 	"github.com/prometheus-community/windows_exporter/internal/collector/iis"
+	"github.com/prometheus-community/windows_exporter/internal/collector/ipsec"
 	"github.com/prometheus-community/windows_exporter/internal/collector/license"
 	"github.com/prometheus-community/windows_exporter/internal/collector/logical_disk"
 	"github.com/prometheus-community/windows_exporter/internal/collector/memory"
@@ -65,6 +66,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/udp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/update"
 	"github.com/prometheus-community/windows_exporter/internal/collector/vmware"
+	"github.com/prometheus-community/windows_exporter/internal/collector/vss"
 )
 
 type Config struct {
@@ -85,6 +87,7 @@ type Config struct {
 	GPU                gpu.Config                `yaml:"gpu"`
 	HyperV             hyperv.Config             `yaml:"hyperv"`
 	IIS                iis.Config                `yaml:"iis"`
+	IPsec              ipsec.Config              `yaml:"ipsec"`
 	License            license.Config            `yaml:"license"`
 	LogicalDisk        logical_disk.Config       `yaml:"logical_disk"`
 	Memory             memory.Config             `yaml:"memory"`
@@ -115,6 +118,7 @@ type Config struct {
 	UDP                udp.Config                `yaml:"udp"`
 	Update             update.Config             `yaml:"update"`
 	Vmware             vmware.Config             `yaml:"vmware"`
+	Vss                vss.Config                `yaml:"vss"`
 }
 
 // ConfigDefaults Is an interface to be used by the external libraries. It holds all ConfigDefaults form all collectors
@@ -138,6 +142,7 @@ var ConfigDefaults = Config{
 	GPU:                gpu.ConfigDefaults,
 	HyperV:             hyperv.ConfigDefaults,
 	IIS:                iis.ConfigDefaults,
+	IPsec:              ipsec.ConfigDefaults,
 	License:            license.ConfigDefaults,
 	LogicalDisk:        logical_disk.ConfigDefaults,
 	Memory:             memory.ConfigDefaults,
@@ -168,4 +173,5 @@ var ConfigDefaults = Config{
 	UDP:                udp.ConfigDefaults,
 	Update:             update.ConfigDefaults,
 	Vmware:             vmware.ConfigDefaults,
+	Vss:                vss.ConfigDefaults,
 }
@@ -39,6 +39,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/gpu"
 	"github.com/prometheus-community/windows_exporter/internal/collector/hyperv"
 	"github.com/prometheus-community/windows_exporter/internal/collector/iis"
+	"github.com/prometheus-community/windows_exporter/internal/collector/ipsec"
 	"github.com/prometheus-community/windows_exporter/internal/collector/license"
 	"github.com/prometheus-community/windows_exporter/internal/collector/logical_disk"
 	"github.com/prometheus-community/windows_exporter/internal/collector/memory"
@@ -69,6 +70,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/udp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/update"
 	"github.com/prometheus-community/windows_exporter/internal/collector/vmware"
+	"github.com/prometheus-community/windows_exporter/internal/collector/vss"
 )
 
 func NewBuilderWithFlags[C Collector](fn BuilderWithFlags[C]) BuilderWithFlags[Collector] {
@@ -96,6 +98,7 @@ var BuildersWithFlags = map[string]BuilderWithFlags[Collector]{
 	gpu.Name:                NewBuilderWithFlags(gpu.NewWithFlags),
 	hyperv.Name:             NewBuilderWithFlags(hyperv.NewWithFlags),
 	iis.Name:                NewBuilderWithFlags(iis.NewWithFlags),
+	ipsec.Name:              NewBuilderWithFlags(ipsec.NewWithFlags),
 	license.Name:            NewBuilderWithFlags(license.NewWithFlags),
 	logical_disk.Name:       NewBuilderWithFlags(logical_disk.NewWithFlags),
 	memory.Name:             NewBuilderWithFlags(memory.NewWithFlags),
@@ -126,6 +129,7 @@ var BuildersWithFlags = map[string]BuilderWithFlags[Collector]{
 	udp.Name:                NewBuilderWithFlags(udp.NewWithFlags),
 	update.Name:             NewBuilderWithFlags(update.NewWithFlags),
 	vmware.Name:             NewBuilderWithFlags(vmware.NewWithFlags),
+	vss.Name:                NewBuilderWithFlags(vss.NewWithFlags),
 }
 
 // Available returns a sorted list of available collectors.
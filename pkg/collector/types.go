@@ -34,10 +34,16 @@ type Collection struct {
 	startTime     time.Time
 	concurrencyCh chan struct{}
 
+	// counterSetMissing contains the collectors which failed to build,
+	// because the performance counter set or WMI class is not available on this host.
+	counterSetMissing map[string]struct{}
+
 	scrapeDurationDesc          *prometheus.Desc
 	collectorScrapeDurationDesc *prometheus.Desc
 	collectorScrapeSuccessDesc  *prometheus.Desc
 	collectorScrapeTimeoutDesc  *prometheus.Desc
+
+	collectorScrapeErrorReasonDesc *prometheus.Desc
 }
 
 type (
@@ -56,3 +62,9 @@ type Collector interface {
 	// Close closes the collector
 	Close() error
 }
+
+// Validator is implemented by collectors, which can check their configuration without accessing PDH or WMI.
+type Validator interface {
+	// Validate checks the configuration of the collector
+	Validate() error
+}